@@ -1,12 +1,20 @@
 package app
 
 import (
+	"context"
+	"log"
 	"take-home-test/app/controllers"
 	"take-home-test/app/repositories"
 	"take-home-test/app/routes"
 	usecase "take-home-test/app/usecases"
+	"take-home-test/migrations"
 	"take-home-test/pkg/config"
 	"take-home-test/pkg/database"
+	"take-home-test/pkg/middleware"
+	"take-home-test/pkg/migration"
+	"take-home-test/pkg/payment"
+	"take-home-test/pkg/scheduler"
+	"time"
 
 	_ "take-home-test/docs" // ✅ PASTIKAN INI ADA
 
@@ -37,16 +45,11 @@ func New() *Main {
 }
 
 func (m *Main) Init() (err error) {
-	// Load environment variables
-	viper.SetConfigFile(".env")
-	err = viper.ReadInConfig()
+	err = m.InitDatabase()
 	if err != nil {
 		return
 	}
 
-	// Load configuration
-	m.cfg = config.NewConfig()
-
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
 		AppName: "Sports Booking API - " + m.cfg.ServiceEnvironment,
@@ -56,26 +59,32 @@ func (m *Main) Init() (err error) {
 	app.Use(logger.New())
 	app.Use(recover.New())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
+		AllowMethods:  "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		ExposeHeaders: "Idempotent-Replayed",
 	}))
 
-	// Database connection - menggunakan config Postgres
-	m.database.Postgres, err = database.GetConnection(m.cfg.Postgres().Read.ToArgs(database.Postgres, database.ReadConn, nil))
-	if err != nil {
-		return
-	}
-
 	// Initialize layers
 	m.repo = repositories.Init(repositories.Options{
 		Postgres: m.database.Postgres,
 		Config:   m.cfg,
 	})
 
+	paymentGateway, err := payment.NewPaymentGateway(
+		m.cfg.PaymentGateway,
+		m.cfg.MidtransServerKey,
+		m.cfg.ServiceEnvironment == "production",
+		m.cfg.ServiceHost,
+	)
+	if err != nil {
+		return
+	}
+
 	m.usecase = usecase.Init(usecase.Options{
-		Repository: m.repo,
-		Config:     m.cfg,
+		Repository:     m.repo,
+		Config:         m.cfg,
+		PaymentGateway: paymentGateway,
 	})
 
 	m.controller = controllers.Init(controllers.Options{
@@ -86,13 +95,88 @@ func (m *Main) Init() (err error) {
 	m.router = app
 
 	// Configure routes
-	routes.ConfigureRouter(app, m.controller)
+	routes.ConfigureRouter(app, m.controller, routes.Middlewares{
+		JWT:         middleware.NewJWTMiddleware(m.cfg, m.usecase.Auth),
+		Idempotency: middleware.NewIdempotencyMiddleware(m.usecase.Idempotency),
+	})
 	return err
 }
 
+// InitDatabase loads the configuration and opens the database connection
+// only, so CLI commands such as `migrate` can run without the HTTP layers.
+func (m *Main) InitDatabase() (err error) {
+	// Load environment variables
+	viper.SetConfigFile(".env")
+	err = viper.ReadInConfig()
+	if err != nil {
+		return
+	}
+
+	// Load configuration
+	m.cfg = config.NewConfig()
+
+	// Database connection - menggunakan config Postgres
+	m.database.Postgres, err = database.GetConnection(m.cfg.Postgres().Read.ToArgs(database.Postgres, database.ReadConn, nil))
+	return
+}
+
+// Migrator returns the schema migrator bound to the Postgres connection and
+// the DB_POSTGRES_SCHEMA search_path.
+func (m *Main) Migrator() (*migration.Migrator, error) {
+	return migration.New(m.database.Postgres, m.cfg.Postgres().Read.Schema, migrations.FS)
+}
+
+// UseCases exposes the business layer to CLI commands once Init has run.
+func (m *Main) UseCases() *usecase.Main {
+	return m.usecase
+}
+
+// Close releases the database connections opened by InitDatabase.
+func (m *Main) Close() {
+	m.close()
+}
+
 func (m *Main) Run() (err error) {
 	defer m.close()
 
+	jobs := scheduler.New(scheduler.Job{
+		Name:     "expire-pending-bookings",
+		Interval: m.cfg.GetBookingExpiryInterval(),
+		Run: func(ctx context.Context) error {
+			expired, err := m.usecase.Booking.ExpirePendingBookings(ctx)
+			if expired > 0 {
+				log.Printf("expired %d unpaid pending bookings", expired)
+			}
+			return err
+		},
+	}, scheduler.Job{
+		Name:     "complete-finished-bookings",
+		Interval: m.cfg.GetBookingExpiryInterval(),
+		Run: func(ctx context.Context) error {
+			completed, err := m.usecase.Booking.CompleteFinishedBookings(ctx)
+			if completed > 0 {
+				log.Printf("completed %d finished bookings", completed)
+			}
+			return err
+		},
+	}, scheduler.Job{
+		Name:     "expire-waitlist-entries",
+		Interval: m.cfg.GetBookingExpiryInterval(),
+		Run: func(ctx context.Context) error {
+			_, err := m.usecase.Waitlist.ExpireStaleEntries(ctx)
+			return err
+		},
+	}, scheduler.Job{
+		Name:     "purge-idempotency-keys",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			_, err := m.usecase.Idempotency.PurgeExpiredKeys(ctx)
+			return err
+		},
+	})
+	jobs.Start(context.Background())
+	defer jobs.Stop()
+
 	// Start server
 	err = m.router.Listen(":" + m.cfg.ServicePort)
 	return
@@ -10,9 +10,15 @@ const (
 	TOKEN_TYPE_BEARER = "Bearer"
 
 	// Auth contexts
-	CTX_USER_ID    = "userID"
-	CTX_USER_EMAIL = "email"
-	CTX_USER_ROLE  = "role"
+	CTX_USER_ID          = "userID"
+	CTX_USER_EMAIL       = "email"
+	CTX_USER_ROLE        = "role"
+	CTX_TOKEN_ID         = "jti"
+	CTX_SESSION_ID       = "sessionID"
+	CTX_TOKEN_EXPIRES_AT = "tokenExpiresAt"
+
+	// Refresh tokens
+	REFRESH_TOKEN_BYTES = 32
 
 	// Password
 	MIN_PASSWORD_LENGTH = 6
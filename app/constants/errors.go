@@ -9,6 +9,30 @@ var (
 	ErrFieldNotFound     = `Field with id '%s' not found`
 	ErrFieldNotFoundByID = `Field with id '%s' not found`
 
+	// Field schedule errors
+	ErrFieldScheduleNotFound = `Field schedule with id '%s' not found`
+
+	// Closure errors
+	ErrFieldBlackoutNotFound = `Field blackout with id '%s' not found`
+	ErrHolidayNotFound       = `Holiday with id '%s' not found`
+
+	// Pricing errors
+	ErrPricingRuleNotFound = `Pricing rule with id '%s' not found`
+
+	// Voucher errors
+	ErrVoucherNotFound = `Voucher '%s' not found`
+
+	// Cart errors
+	ErrCartNotFound     = `You have no open cart`
+	ErrCartItemNotFound = `Booking with id '%s' is not in your cart`
+
+	// Split payment errors
+	ErrShareNotFound = `Payment share with id '%s' not found`
+
+	// Waitlist errors
+	ErrWaitlistEntryNotFound = `Waitlist entry with id '%s' not found`
+	ErrNotificationNotFound  = `Notification with id '%s' not found`
+
 	// Booking errors
 	ErrBookingNotFound     = `Booking with id '%s' not found`
 	ErrBookingNotFoundByID = `Booking with id '%s' not found`
@@ -17,6 +41,11 @@ var (
 	ErrPaymentNotFound          = `Payment with id '%s' not found`
 	ErrPaymentNotFoundByID      = `Payment with id '%s' not found`
 	ErrPaymentNotFoundByBooking = `Payment for booking id '%s' not found`
+	ErrPaymentAttemptNotFound   = `Payment attempt '%s' not found`
+	ErrRefundNotFound           = `Refund '%s' not found`
+
+	// Idempotency errors
+	ErrIdempotencyKeyNotFound = `Idempotency-Key '%s' not found`
 )
 
 const (
@@ -26,15 +55,20 @@ const (
 	ErrInvalidToken       = "Invalid or expired token"
 	ErrMissingToken       = "Authorization token is required"
 	ErrInvalidTokenFormat = "Invalid authorization token format"
+	ErrInvalidRefresh     = "Invalid or expired refresh token"
+	ErrSessionRevoked     = "Session has been revoked"
 
 	// User errors
 	ErrUnauthorizedAccess  = "Unauthorized access"
 	ErrAdminAccessRequired = "Admin access required"
+	ErrCannotChangeOwnRole = "You cannot change your own role"
+	ErrLastAdmin           = "Cannot demote the last admin"
 
 	// Field errors
 	ErrDuplicateFieldName = "Field with this name already exists"
 	ErrFieldRequired      = "Field %s is required"
 	ErrInvalidPrice       = "Price per hour must be greater than 0"
+	ErrInvalidDeposit     = "Deposit percent must be between 0 and 100"
 
 	// Booking errors
 	ErrTimeSlotOverlap   = "Time slot is already booked for this field"
@@ -42,11 +76,119 @@ const (
 	ErrBookingInPast     = "Booking cannot be in the past"
 	ErrMinimumDuration   = "Booking duration must be at least 1 hour"
 	ErrFieldNotAvailable = "Field is not available for booking"
+	ErrBookingCanceled   = "Booking has already been canceled"
+	ErrBookingStarted    = "Booking has already started and can no longer be canceled"
+	ErrInvalidSlot       = "Slot must be a duration between %s and %s, e.g. 60m"
+	ErrInvalidDateTime   = "Invalid %s: use RFC3339 or YYYY-MM-DD"
+	ErrRangeTooLong      = "Availability range cannot exceed %d days"
+	ErrOutsideOpenHours  = "Booking is outside the field's opening hours"
+
+	// Booking management errors
+	ErrBookingNotStarted = "Booking has not started yet"
+	ErrWalkInCustomer    = "Either user_id or customer_name is required"
+
+	// Reschedule errors
+	ErrBookingNotReschedulable = "Only pending, partially paid, paid or confirmed bookings can be rescheduled"
+	ErrSameSlot                = "The new time is the same as the booking's current time"
+	ErrRescheduleStarted       = "Booking has already started and can no longer be rescheduled"
+
+	// Recurring booking errors
+	ErrInvalidFrequency       = "Invalid frequency '%s': use weekly or biweekly"
+	ErrRecurrenceEndRequired  = "Either count or until is required"
+	ErrInvalidRecurrenceUntil = "Invalid until '%s': use YYYY-MM-DD"
+	ErrTooFewOccurrences      = "A recurring booking needs at least 2 occurrences"
+	ErrTooManyOccurrences     = "A recurring booking can have at most %d occurrences"
+	ErrSeriesConflicts        = "%d of the %d occurrences cannot be booked"
+
+	// Cart errors
+	ErrCartEmpty     = "Cart is empty"
+	ErrCartFull      = "A cart can hold at most %d bookings"
+	ErrBookingInCart = "Booking is part of a cart: check out the cart to pay for it"
+
+	// Split payment errors
+	ErrSplitNotAllowed    = "Only an unpaid booking paid for on its own can be split"
+	ErrAlreadySplit       = "Booking payment is already split"
+	ErrInvalidSplitEmails = "Invite between 1 and %d participants by distinct email addresses other than your own"
+	ErrInvalidDeadline    = "Deadline must be in the future and before the booking starts"
+	ErrPaymentSplit       = "Booking payment is split: participants pay their shares and the owner covers the rest"
+	ErrShareClosed        = "Payment share is already %s"
+	ErrDeadlinePassed     = "Split payment deadline has passed"
+	ErrNotSplit           = "Booking payment is not split"
+
+	// Waitlist errors
+	ErrSlotAvailable       = "Time slot is available: book it directly instead"
+	ErrAlreadyWaitlisted   = "You are already on the waitlist for this time slot"
+	ErrWaitlistEntryClosed = "Waitlist entry is already %s"
+	ErrNoWaitlistOffer     = "Waitlist entry has no open offer"
+	ErrOfferExpired        = "Waitlist offer has expired"
+	ErrBookingOnHold       = "Booking is a waitlist offer: confirm it before paying"
+
+	// Status errors
+	ErrInvalidStatusTransition = "Cannot change %s status from '%s' to '%s'"
+
+	// Field schedule errors
+	ErrInvalidDayOfWeek   = "Invalid day_of_week '%s': use monday to sunday"
+	ErrInvalidOpeningTime = "Invalid opening hours %s-%s: use HH:MM with close_time after open_time"
+	ErrScheduleOverlap    = "Opening hours on %s overlap: %s-%s and %s-%s"
+
+	// Closure errors
+	ErrFieldBlackedOut     = "Field is closed for maintenance from %s to %s"
+	ErrHolidayClosed       = "The venue is closed on %s (%s)"
+	ErrInvalidHolidayDate  = "Invalid holiday date '%s': use YYYY-MM-DD"
+	ErrHolidayNameRequired = "Holiday name is required"
+	ErrInvalidCalendar     = "Invalid iCalendar file: %v"
+	ErrCalendarRequired    = "An iCalendar file is required"
+	ErrTooManyHolidays     = "An iCalendar import cannot add more than %d dates"
+
+	// Pricing errors
+	ErrInvalidDayType     = "Invalid day_type '%s': use all, weekday, weekend, holiday or monday to sunday"
+	ErrInvalidPricingTime = "Invalid pricing hours %s-%s: use HH:MM with end_time after start_time"
+	ErrInvalidRulePrice   = "Price per hour cannot be negative"
+	ErrQuoteTimeRequired  = "Both start and end are required"
+	ErrQuoteRangeTooLong  = "Quote range cannot exceed %d days"
+
+	// Voucher errors
+	ErrDuplicateVoucherCode   = "Voucher code '%s' already exists"
+	ErrInvalidVoucherCode     = "Voucher code must be 3 to 32 letters, digits, '-' or '_'"
+	ErrInvalidDiscountType    = "Invalid discount_type '%s': use percentage or fixed"
+	ErrInvalidDiscountValue   = "Discount value must be greater than 0, and at most 100 for a percentage"
+	ErrInvalidVoucherLimit    = "Voucher limits and amounts cannot be negative"
+	ErrInvalidVoucherValidity = "valid_until must be after valid_from"
+	ErrUsageLimitBelowUsed    = "usage_limit cannot be lower than the %d times the voucher was already used"
+	ErrVoucherInvalid         = "Voucher code '%s' is not valid"
+	ErrVoucherInactive        = "Voucher '%s' is no longer active"
+	ErrVoucherNotStarted      = "Voucher '%s' is not valid yet"
+	ErrVoucherExpired         = "Voucher '%s' has expired"
+	ErrVoucherFieldNotAllowed = "Voucher '%s' cannot be used for this field"
+	ErrVoucherMinAmount       = "Voucher '%s' requires a booking of at least %d"
+	ErrVoucherUsedUp          = "Voucher '%s' has been fully redeemed"
+	ErrVoucherUserLimit       = "You have already used voucher '%s' the maximum number of times"
 
 	// Payment errors
 	ErrPaymentAlreadyProcessed = "Payment has already been processed"
 	ErrInvalidPaymentMethod    = "Invalid payment method"
 	ErrPaymentFailed           = "Payment processing failed"
+	ErrFakeGatewayDisabled     = "Fake payment gateway is not enabled"
+	ErrInvalidNotification     = "Invalid notification payload: %s missing"
+	ErrInvalidSignature        = "Invalid notification signature"
+	ErrGrossAmountMismatch     = "Gross amount %s does not match payment amount %d"
+	ErrRefundFailed            = "Booking was canceled but the refund failed: %v"
+	ErrRefundRejected          = "Refund was rejected by the payment gateway: %v"
+	ErrRefundInProgress        = "Refund '%s' is already in progress or done"
+	ErrNothingToRefund         = "Payment has nothing left to refund"
+	ErrInvalidRefundAmount     = "Refund amount must be between 1 and %d"
+	ErrInvalidGatewayAmount    = "Invalid amount '%s' reported by the payment gateway"
+	ErrInvalidCashAmount       = "Cash amount must be between 1 and %d"
+
+	// Idempotency errors
+	ErrIdempotencyKeyReused     = "Idempotency-Key '%s' was already used for a different request"
+	ErrIdempotencyKeyInProgress = "A request with Idempotency-Key '%s' is still being processed"
+
+	// List errors
+	ErrInvalidSort          = "Invalid sort '%s': use one of %s, optionally prefixed with '-'"
+	ErrInvalidPriceRange    = "min_price cannot be greater than max_price"
+	ErrInvalidBookingStatus = "Invalid booking status '%s'"
+	ErrInvalidUUIDParam     = "Invalid %s: must be a UUID"
 
 	// Validation errors
 	ErrInvalidUUID     = "Invalid UUID format"
@@ -6,15 +6,98 @@ const (
 	ROLE_USER  = "user"
 
 	// Booking statuses
-	BOOKING_STATUS_PENDING   = "pending"
-	BOOKING_STATUS_PAID      = "paid"
-	BOOKING_STATUS_CANCELED  = "canceled"
-	BOOKING_STATUS_CONFIRMED = "confirmed"
+	BOOKING_STATUS_PENDING        = "pending"
+	BOOKING_STATUS_PAID           = "paid"
+	BOOKING_STATUS_CANCELED       = "canceled"
+	BOOKING_STATUS_CONFIRMED      = "confirmed"
+	BOOKING_STATUS_NO_SHOW        = "no_show"
+	BOOKING_STATUS_COMPLETED      = "completed"
+	BOOKING_STATUS_HELD           = "held"
+	BOOKING_STATUS_PARTIALLY_PAID = "partially_paid"
+
+	// Booking channels
+	BOOKING_CHANNEL_ONLINE  = "online"
+	BOOKING_CHANNEL_WALK_IN = "walk_in"
+
+	// Recurring booking frequencies
+	RECURRENCE_WEEKLY   = "weekly"
+	RECURRENCE_BIWEEKLY = "biweekly"
+
+	// Recurring booking limits
+	MAX_RECURRING_OCCURRENCES = 52
+
+	// Order (cart) statuses
+	ORDER_STATUS_OPEN        = "open"
+	ORDER_STATUS_CHECKED_OUT = "checked_out"
+	ORDER_STATUS_EXPIRED     = "expired"
+
+	// Cart limits
+	MAX_CART_ITEMS = 10
+
+	// Waitlist entry statuses
+	WAITLIST_STATUS_WAITING  = "waiting"
+	WAITLIST_STATUS_OFFERED  = "offered"
+	WAITLIST_STATUS_BOOKED   = "booked"
+	WAITLIST_STATUS_EXPIRED  = "expired"
+	WAITLIST_STATUS_CANCELED = "canceled"
+
+	// Split payment share statuses
+	SHARE_STATUS_PENDING  = "pending"
+	SHARE_STATUS_PAID     = "paid"
+	SHARE_STATUS_COVERED  = "covered"
+	SHARE_STATUS_CANCELED = "canceled"
+
+	// Split payment limits: the owner and up to 9 invited participants
+	MAX_SPLIT_SHARES = 10
+
+	// Notification types
+	NOTIFICATION_WAITLIST_OFFER = "waitlist_offer"
+	NOTIFICATION_SPLIT_INVITE   = "split_invite"
 
 	// Payment statuses
 	PAYMENT_STATUS_PENDING = "pending"
 	PAYMENT_STATUS_SUCCESS = "success"
 	PAYMENT_STATUS_FAILED  = "failed"
+	PAYMENT_STATUS_EXPIRED = "expired"
+
+	PAYMENT_STATUS_REFUNDED           = "refunded"
+	PAYMENT_STATUS_PARTIALLY_REFUNDED = "partially_refunded"
+
+	// Status change sources: what triggered a booking or payment transition
+	STATUS_SOURCE_USER    = "user"
+	STATUS_SOURCE_ADMIN   = "admin"
+	STATUS_SOURCE_GATEWAY = "gateway"
+	STATUS_SOURCE_SYSTEM  = "system"
+
+	// Refund statuses
+	REFUND_STATUS_PENDING = "pending"
+	REFUND_STATUS_SUCCESS = "success"
+	REFUND_STATUS_FAILED  = "failed"
+
+	// Refund sources: who started the refund
+	REFUND_SOURCE_ADMIN        = "admin"
+	REFUND_SOURCE_CANCELLATION = "cancellation"
+	REFUND_SOURCE_GATEWAY      = "gateway"
+
+	// Idempotency key statuses
+	IDEMPOTENCY_STATUS_PROCESSING = "processing"
+	IDEMPOTENCY_STATUS_COMPLETED  = "completed"
+
+	// Availability slot statuses
+	SLOT_STATUS_FREE   = "free"
+	SLOT_STATUS_TAKEN  = "taken"
+	SLOT_STATUS_PAST   = "past"
+	SLOT_STATUS_CLOSED = "closed"
+
+	// Pricing rule day types; a day name (monday to sunday) is valid as well
+	PRICING_DAY_ALL     = "all"
+	PRICING_DAY_WEEKDAY = "weekday"
+	PRICING_DAY_WEEKEND = "weekend"
+	PRICING_DAY_HOLIDAY = "holiday"
+
+	// Voucher discount types
+	DISCOUNT_TYPE_PERCENTAGE = "percentage"
+	DISCOUNT_TYPE_FIXED      = "fixed"
 
 	// Payment methods
 	PAYMENT_METHOD_CASH        = "cash"
@@ -22,10 +105,15 @@ const (
 	PAYMENT_METHOD_CREDIT_CARD = "credit_card"
 	PAYMENT_METHOD_DEBIT_CARD  = "debit_card"
 
+	// List pagination
+	DEFAULT_PAGE_SIZE = 20
+	MAX_PAGE_SIZE     = 100
+
 	// Time formats
 	TIME_FORMAT_RFC3339 = "2006-01-02T15:04:05Z"
 	TIME_FORMAT_ISO8601 = "2006-01-02T15:04:05-07:00"
 	TIME_FORMAT_SIMPLE  = "2006-01-02 15:04:05"
+	DATE_FORMAT         = "2006-01-02"
 
 	// Database constraints
 	UNIQUE_CONSTRAINT_USER_EMAIL = "users_email_key"
@@ -48,6 +136,10 @@ var (
 		BOOKING_STATUS_PAID,
 		BOOKING_STATUS_CANCELED,
 		BOOKING_STATUS_CONFIRMED,
+		BOOKING_STATUS_NO_SHOW,
+		BOOKING_STATUS_COMPLETED,
+		BOOKING_STATUS_HELD,
+		BOOKING_STATUS_PARTIALLY_PAID,
 	}
 
 	// Valid payment statuses
@@ -55,6 +147,9 @@ var (
 		PAYMENT_STATUS_PENDING,
 		PAYMENT_STATUS_SUCCESS,
 		PAYMENT_STATUS_FAILED,
+		PAYMENT_STATUS_EXPIRED,
+		PAYMENT_STATUS_REFUNDED,
+		PAYMENT_STATUS_PARTIALLY_REFUNDED,
 	}
 
 	// Valid payment methods
@@ -65,7 +160,7 @@ var (
 		PAYMENT_METHOD_DEBIT_CARD,
 	}
 
-	// Days of week (for potential scheduling features)
+	// Days of week, as used by field opening hours
 	ArrayDays = []string{
 		"monday",
 		"tuesday",
@@ -16,6 +16,8 @@ type authController struct {
 type AuthInterface interface {
 	Register(ctx *fiber.Ctx) error
 	Login(ctx *fiber.Ctx) error
+	Refresh(ctx *fiber.Ctx) error
+	Logout(ctx *fiber.Ctx) error
 }
 
 // Register godoc
@@ -44,10 +46,6 @@ func (ctrl *authController) Register(ctx *fiber.Ctx) error {
 		return helpers.BadRequestResponse(ctx, err.Error())
 	}
 
-	if reqBody.Role == "" {
-		reqBody.Role = constants.ROLE_USER
-	}
-
 	resBody, err = ctrl.Options.UseCases.Auth.Register(ctx.Context(), reqBody)
 	if err != nil {
 		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
@@ -89,3 +87,67 @@ func (ctrl *authController) Login(ctx *fiber.Ctx) error {
 
 	return helpers.StandardResponse(ctx, fiber.StatusOK, []string{constants.LOGIN_SUCCESS_MESSAGE}, resBody, nil)
 }
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated and can only be used once.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh token request"
+// @Success 200 {object} models.BasicResponse{data=models.TokenResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Router /auth/refresh [post]
+func (ctrl *authController) Refresh(ctx *fiber.Ctx) error {
+	var (
+		reqBody models.RefreshTokenRequest
+		resBody *models.TokenResponse
+		err     error
+	)
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if reqBody.RefreshToken == "" {
+		return helpers.BadRequestResponse(ctx, "Refresh token is required")
+	}
+
+	resBody, err = ctrl.Options.UseCases.Auth.Refresh(ctx.Context(), reqBody.RefreshToken)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, resBody)
+}
+
+// Logout godoc
+// @Summary Logout user
+// @Description Revoke the current session and its access token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Router /auth/logout [post]
+func (ctrl *authController) Logout(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	err := ctrl.Options.UseCases.Auth.Logout(
+		ctx.Context(),
+		userID,
+		helpers.GetTokenIDFromContext(ctx),
+		helpers.GetSessionIDFromContext(ctx),
+		helpers.GetTokenExpiresAtFromContext(ctx),
+	)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.StandardResponse(ctx, fiber.StatusOK, []string{constants.LOGOUT_SUCCESS_MESSAGE}, nil, nil)
+}
@@ -7,6 +7,7 @@ import (
 	"take-home-test/pkg/customerror"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type bookingController struct {
@@ -15,17 +16,26 @@ type bookingController struct {
 
 type BookingInterface interface {
 	CreateBooking(ctx *fiber.Ctx) error
+	CreateRecurringBooking(ctx *fiber.Ctx) error
 	GetBookingByID(ctx *fiber.Ctx) error
 	GetUserBookings(ctx *fiber.Ctx) error
+	CancelBooking(ctx *fiber.Ctx) error
+	RescheduleBooking(ctx *fiber.Ctx) error
+	GetBookings(ctx *fiber.Ctx) error
+	GetBookingDetails(ctx *fiber.Ctx) error
+	CreateWalkInBooking(ctx *fiber.Ctx) error
+	ConfirmBooking(ctx *fiber.Ctx) error
+	MarkNoShow(ctx *fiber.Ctx) error
 }
 
 // CreateBooking godoc
 // @Summary Create new booking
-// @Description Create a new field booking
+// @Description Create a new field booking. The price follows the field's pricing rules; an optional voucher_code is applied to it, and a booking left with nothing to pay is marked paid right away.
 // @Tags Bookings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
 // @Param request body models.CreateBookingRequest true "Booking data"
 // @Success 201 {object} models.BasicResponse{data=models.BookingResponse}
 // @Failure 400 {object} models.BasicResponse
@@ -58,7 +68,7 @@ func (ctrl *bookingController) CreateBooking(ctx *fiber.Ctx) error {
 		reqBody.StartTime.Format(constants.TIME_FORMAT_RFC3339), // ✅ GUNAKAN CONSTANTS
 		reqBody.EndTime.Format(constants.TIME_FORMAT_RFC3339),
 	); err != nil {
-		return helpers.BadRequestResponse(ctx, err.Error())
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
 	}
 
 	resBody, err = ctrl.Options.UseCases.Booking.CreateBooking(ctx.Context(), userID, reqBody)
@@ -69,6 +79,48 @@ func (ctrl *bookingController) CreateBooking(ctx *fiber.Ctx) error {
 	return helpers.CreatedResponse(ctx, resBody)
 }
 
+// CreateRecurringBooking godoc
+// @Summary Create recurring booking
+// @Description Book the same slot weekly or biweekly, count times and/or until a date (YYYY-MM-DD in the venue timezone, inclusive), at most 52 occurrences. Every occurrence is checked against the field's opening hours, closures and existing bookings; if any cannot be booked nothing is booked and the 409 response lists the conflicting occurrences. The whole series is held and paid for with a single payment, started through any of its bookings.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param request body models.RecurringBookingRequest true "Recurring booking data"
+// @Success 201 {object} models.BasicResponse{data=models.BookingSeriesResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse{data=models.BookingConflictsResponse}
+// @Router /bookings/recurring [post]
+func (ctrl *bookingController) CreateRecurringBooking(ctx *fiber.Ctx) error {
+	var reqBody models.RecurringBookingRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if reqBody.FieldID == uuid.Nil {
+		return helpers.BadRequestResponse(ctx, "Field ID is required")
+	}
+
+	resBody, conflicts, err := ctrl.Options.UseCases.Booking.CreateRecurringBooking(ctx.Context(), userID, reqBody)
+	if err != nil {
+		var data interface{}
+		if len(conflicts) > 0 {
+			data = models.BookingConflictsResponse{Conflicts: conflicts}
+		}
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, data, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, resBody)
+}
+
 // GetBookingByID godoc
 // @Summary Get booking by ID
 // @Description Get booking details by ID
@@ -105,23 +157,325 @@ func (ctrl *bookingController) GetBookingByID(ctx *fiber.Ctx) error {
 
 // GetUserBookings godoc
 // @Summary Get user bookings
-// @Description Get all bookings for the authenticated user
+// @Description Get a page of the authenticated user's bookings, optionally filtered by status, field and date range. from and to accept RFC3339 or a date in the venue timezone and match bookings overlapping the range; a date used as "to" includes the whole day.
 // @Tags Bookings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.BasicResponse{data=[]models.BookingResponse}
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Comma-separated sort keys (start_time, end_time, status, created_at); prefix with - for descending" default(-start_time)
+// @Param status query string false "Comma-separated statuses, e.g. pending,paid"
+// @Param field_id query string false "Field ID"
+// @Param from query string false "Range start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Range end (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} models.ResponseWithPaginate{data=[]models.BookingResponse}
+// @Failure 400 {object} models.BasicResponse
 // @Router /bookings/user [get]
 func (ctrl *bookingController) GetUserBookings(ctx *fiber.Ctx) error {
+	var reqQuery models.BookingListRequest
+
 	userID := helpers.GetUserIDFromContext(ctx)
 	if userID == "" {
 		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
 	}
 
-	bookings, err := ctrl.Options.UseCases.Booking.GetUserBookings(ctx.Context(), userID)
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	bookings, pagination, err := ctrl.Options.UseCases.Booking.GetUserBookings(ctx.Context(), userID, reqQuery)
 	if err != nil {
 		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
 	}
 
-	return helpers.SuccessResponse(ctx, bookings)
+	return helpers.SuccessResponseWithPagination(ctx, bookings, pagination)
+}
+
+// CancelBooking godoc
+// @Summary Cancel booking
+// @Description Cancel a booking that has not started yet (owner or admin). A paid booking is refunded according to the cancellation policy: full refund until REFUND_FULL_BEFORE before the start, REFUND_PARTIAL_PERCENT until REFUND_CUTOFF, nothing afterwards.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Param request body models.CancelBookingRequest false "Cancellation reason"
+// @Success 200 {object} models.BasicResponse{data=models.CancelBookingResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/cancel [post]
+func (ctrl *bookingController) CancelBooking(ctx *fiber.Ctx) error {
+	var reqBody models.CancelBookingRequest
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&reqBody); err != nil {
+			return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+		}
+	}
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	userRole := helpers.GetUserRoleFromContext(ctx)
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	if userRole != constants.ROLE_ADMIN && booking.UserID.String() != userID {
+		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
+	}
+
+	resBody, err := ctrl.Options.UseCases.Booking.CancelBooking(ctx.Context(), id, helpers.GetActorFromContext(ctx), reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, resBody)
+}
+
+// RescheduleBooking godoc
+// @Summary Reschedule booking
+// @Description Move a pending, partially paid, paid or confirmed booking that has not started yet to another slot of the same field (owner or admin). The new slot is validated like a new booking, apart from overlapping the booking itself, and priced again. An unpaid booking is paid at the new amount as usual; a paid booking that became more expensive gets a transaction for the difference, and what was paid beyond the new amount is kept as credit. A booking secured with a deposit keeps its balance to be paid later.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Param request body models.RescheduleBookingRequest true "New time"
+// @Success 200 {object} models.BasicResponse{data=models.RescheduleBookingResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /bookings/{id}/reschedule [patch]
+func (ctrl *bookingController) RescheduleBooking(ctx *fiber.Ctx) error {
+	var reqBody models.RescheduleBookingRequest
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	userRole := helpers.GetUserRoleFromContext(ctx)
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	if userRole != constants.ROLE_ADMIN && booking.UserID.String() != userID {
+		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
+	}
+
+	resBody, err := ctrl.Options.UseCases.Booking.RescheduleBooking(ctx.Context(), id, helpers.GetActorFromContext(ctx), reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, resBody)
+}
+
+// GetBookings godoc
+// @Summary List all bookings
+// @Description Get a page of the bookings of every user with their customer, field and payment (admin only). q searches the customer's name, email and phone and the field name.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Comma-separated sort keys (start_time, end_time, status, created_at); prefix with - for descending" default(-start_time)
+// @Param status query string false "Comma-separated statuses, e.g. paid,confirmed"
+// @Param field_id query string false "Field ID"
+// @Param user_id query string false "User ID"
+// @Param q query string false "Search text"
+// @Param from query string false "Range start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Range end (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} models.ResponseWithPaginate{data=[]models.BookingDetailResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Router /bookings [get]
+func (ctrl *bookingController) GetBookings(ctx *fiber.Ctx) error {
+	var reqQuery models.AdminBookingListRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	bookings, pagination, err := ctrl.Options.UseCases.Booking.GetBookings(ctx.Context(), reqQuery)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponseWithPagination(ctx, bookings, pagination)
+}
+
+// GetBookingDetails godoc
+// @Summary Get booking details
+// @Description Get a booking with its customer, field and payment, including payment attempts, refunds and the status history of the booking and its payment (admin only)
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.BookingDetailResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/details [get]
+func (ctrl *bookingController) GetBookingDetails(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingDetails(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, booking)
+}
+
+// CreateWalkInBooking godoc
+// @Summary Create walk-in booking
+// @Description Book a field for a customer at the counter (admin only). Pass user_id for a customer with an account, otherwise customer_name. The booking is confirmed right away; with a payment_method it is also recorded as paid in full.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param request body models.WalkInBookingRequest true "Walk-in booking data"
+// @Success 201 {object} models.BasicResponse{data=models.BookingDetailResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /bookings/walk-in [post]
+func (ctrl *bookingController) CreateWalkInBooking(ctx *fiber.Ctx) error {
+	var reqBody models.WalkInBookingRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if reqBody.FieldID == uuid.Nil {
+		return helpers.BadRequestResponse(ctx, "Field ID is required")
+	}
+
+	if reqBody.PaymentMethod != "" && !helpers.Contains(constants.ValidPaymentMethods, reqBody.PaymentMethod) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidPaymentMethod)
+	}
+
+	if err := ctrl.Options.UseCases.Validate.IsValidBookingTime(
+		ctx.Context(),
+		reqBody.FieldID.String(),
+		reqBody.StartTime.Format(constants.TIME_FORMAT_RFC3339),
+		reqBody.EndTime.Format(constants.TIME_FORMAT_RFC3339),
+	); err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	resBody, err := ctrl.Options.UseCases.Booking.CreateWalkInBooking(ctx.Context(), userID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, resBody)
+}
+
+// ConfirmBooking godoc
+// @Summary Confirm booking
+// @Description Confirm a pending or paid booking by hand, e.g. one that will be paid at the venue (admin only). A confirmed booking no longer expires when its payment hold runs out.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.BookingResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/confirm [post]
+func (ctrl *bookingController) ConfirmBooking(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	booking, err := ctrl.Options.UseCases.Booking.ConfirmBooking(ctx.Context(), userID, id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, booking)
+}
+
+// MarkNoShow godoc
+// @Summary Mark booking as no-show
+// @Description Record that the customer of a paid, confirmed or completed booking did not turn up (admin only). Only possible once the booking has started; nothing is refunded.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.BookingResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/no-show [post]
+func (ctrl *bookingController) MarkNoShow(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	booking, err := ctrl.Options.UseCases.Booking.MarkNoShow(ctx.Context(), userID, id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, booking)
 }
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type cartController struct {
+	Options Options
+}
+
+type CartInterface interface {
+	GetCart(ctx *fiber.Ctx) error
+	AddCartItem(ctx *fiber.Ctx) error
+	RemoveCartItem(ctx *fiber.Ctx) error
+	Checkout(ctx *fiber.Ctx) error
+}
+
+// GetCart godoc
+// @Summary Get cart
+// @Description Get the authenticated user's open cart with its bookings and total.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse{data=models.CartResponse}
+// @Failure 401 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /cart [get]
+func (ctrl *cartController) GetCart(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	cart, err := ctrl.Options.UseCases.Cart.GetCart(ctx.Context(), userID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, cart)
+}
+
+// AddCartItem godoc
+// @Summary Add cart item
+// @Description Hold a slot in the authenticated user's cart, opening one when there is none. The slot is checked like a new booking and held as a pending booking until the cart expires, BOOKING_HOLD_TTL after it was opened. A cart holds at most 10 bookings, on any fields.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CartItemRequest true "Slot to add"
+// @Success 201 {object} models.BasicResponse{data=models.CartResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /cart/items [post]
+func (ctrl *cartController) AddCartItem(ctx *fiber.Ctx) error {
+	var reqBody models.CartItemRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if reqBody.FieldID == uuid.Nil {
+		return helpers.BadRequestResponse(ctx, "Field ID is required")
+	}
+
+	cart, err := ctrl.Options.UseCases.Cart.AddCartItem(ctx.Context(), userID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, cart)
+}
+
+// RemoveCartItem godoc
+// @Summary Remove cart item
+// @Description Remove a booking from the authenticated user's open cart, canceling it and freeing its slot.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param booking_id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.CartResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /cart/items/{booking_id} [delete]
+func (ctrl *cartController) RemoveCartItem(ctx *fiber.Ctx) error {
+	bookingID := ctx.Params("booking_id")
+
+	if !helpers.IsValidUUID(bookingID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	cart, err := ctrl.Options.UseCases.Cart.RemoveCartItem(ctx.Context(), helpers.GetUserIDFromContext(ctx), bookingID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, cart)
+}
+
+// Checkout godoc
+// @Summary Check out cart
+// @Description Close the authenticated user's open cart and pay for all of its bookings with a single payment. Its bookings are held for another BOOKING_HOLD_TTL and the returned transaction lists each of them as an item; once it settles every booking of the cart is paid. When the transaction cannot be created it can be retried through POST /payments/{booking_id}/transaction with any booking of the cart.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Success 200 {object} models.BasicResponse{data=models.CheckoutResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /cart/checkout [post]
+func (ctrl *cartController) Checkout(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	checkout, err := ctrl.Options.UseCases.Cart.Checkout(ctx.Context(), userID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, checkout)
+}
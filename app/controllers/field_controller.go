@@ -19,11 +19,26 @@ type FieldInterface interface {
 	GetFieldByID(ctx *fiber.Ctx) error
 	UpdateField(ctx *fiber.Ctx) error
 	DeleteField(ctx *fiber.Ctx) error
+	GetFieldAvailability(ctx *fiber.Ctx) error
+	GetFieldSchedule(ctx *fiber.Ctx) error
+	ReplaceFieldSchedule(ctx *fiber.Ctx) error
+	AddFieldSchedule(ctx *fiber.Ctx) error
+	DeleteFieldSchedule(ctx *fiber.Ctx) error
+	GetFieldBlackouts(ctx *fiber.Ctx) error
+	CreateFieldBlackout(ctx *fiber.Ctx) error
+	GetBlackoutConflicts(ctx *fiber.Ctx) error
+	CancelBlackoutConflicts(ctx *fiber.Ctx) error
+	DeleteFieldBlackout(ctx *fiber.Ctx) error
+	GetPricingRules(ctx *fiber.Ctx) error
+	CreatePricingRule(ctx *fiber.Ctx) error
+	UpdatePricingRule(ctx *fiber.Ctx) error
+	DeletePricingRule(ctx *fiber.Ctx) error
+	GetQuote(ctx *fiber.Ctx) error
 }
 
 // CreateField godoc
 // @Summary Create new field
-// @Description Create a new sports field. ADMIN ACCESS ONLY - Regular users cannot create fields.
+// @Description Create a new sports field. ADMIN ACCESS ONLY - Regular users cannot create fields. A deposit_percent between 1 and 100 lets bookings of the field, including recurring series and carts, be secured with that share of their amount, the rest being paid later; 0 asks for the full amount.
 // @Tags Fields
 // @Accept json
 // @Produce json
@@ -60,6 +75,10 @@ func (ctrl *fieldController) CreateField(ctx *fiber.Ctx) error {
 		return helpers.BadRequestResponse(ctx, constants.ErrInvalidPrice)
 	}
 
+	if reqBody.DepositPercent < 0 || reqBody.DepositPercent > 100 {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidDeposit)
+	}
+
 	resBody, err = ctrl.Options.UseCases.Field.CreateField(ctx.Context(), reqBody)
 	if err != nil {
 
@@ -71,19 +90,33 @@ func (ctrl *fieldController) CreateField(ctx *fiber.Ctx) error {
 
 // GetFields godoc
 // @Summary Get all fields
-// @Description Get list of all available sports fields. PUBLIC ACCESS - No authentication required.
+// @Description Get a page of the available sports fields, optionally filtered by name, location and price range. PUBLIC ACCESS - No authentication required.
 // @Tags Fields
 // @Accept json
 // @Produce json
-// @Success 200 {object} models.BasicResponse{data=[]models.FieldResponse}
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Comma-separated sort keys (name, location, price_per_hour, created_at); prefix with - for descending" default(name)
+// @Param name query string false "Part of the field name"
+// @Param location query string false "Part of the location"
+// @Param min_price query int false "Minimum price per hour"
+// @Param max_price query int false "Maximum price per hour"
+// @Success 200 {object} models.ResponseWithPaginate{data=[]models.FieldResponse}
+// @Failure 400 {object} models.BasicResponse
 // @Router /fields [get]
 func (ctrl *fieldController) GetFields(ctx *fiber.Ctx) error {
-	fields, err := ctrl.Options.UseCases.Field.GetFields(ctx.Context())
+	var reqQuery models.FieldListRequest
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	fields, pagination, err := ctrl.Options.UseCases.Field.GetFields(ctx.Context(), reqQuery)
 	if err != nil {
 		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
 	}
 
-	return helpers.SuccessResponse(ctx, fields)
+	return helpers.SuccessResponseWithPagination(ctx, fields, pagination)
 }
 
 // GetFieldByID godoc
@@ -111,9 +144,44 @@ func (ctrl *fieldController) GetFieldByID(ctx *fiber.Ctx) error {
 	return helpers.SuccessResponse(ctx, field)
 }
 
+// GetFieldAvailability godoc
+// @Summary Get field availability
+// @Description List the free and taken slots of a field between from and to. PUBLIC ACCESS - No authentication required. A plain date (YYYY-MM-DD) is read in the venue timezone; a date used as "to" includes the whole day. Slots outside opening hours, during a maintenance blackout or on a holiday are closed. Defaults to today with 60 minute slots.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Param id path string true "Field ID (UUID format)"
+// @Param from query string false "Range start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Range end (RFC3339 or YYYY-MM-DD)"
+// @Param slot query string false "Slot length, e.g. 30m or 60m"
+// @Success 200 {object} models.BasicResponse{data=models.FieldAvailabilityResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/availability [get]
+func (ctrl *fieldController) GetFieldAvailability(ctx *fiber.Ctx) error {
+	var reqQuery models.FieldAvailabilityRequest
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	availability, err := ctrl.Options.UseCases.Field.GetFieldAvailability(ctx.Context(), id, reqQuery)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, availability)
+}
+
 // UpdateField godoc
 // @Summary Update field
-// @Description Update sports field information. ADMIN ACCESS ONLY - Regular users cannot update fields.
+// @Description Update sports field information. ADMIN ACCESS ONLY - Regular users cannot update fields. A deposit_percent between 1 and 100 lets bookings of the field, including recurring series and carts, be secured with that share of their amount, the rest being paid later; 0 asks for the full amount.
 // @Tags Fields
 // @Accept json
 // @Produce json
@@ -160,6 +228,10 @@ func (ctrl *fieldController) UpdateField(ctx *fiber.Ctx) error {
 		return helpers.BadRequestResponse(ctx, constants.ErrInvalidPrice)
 	}
 
+	if reqBody.DepositPercent < 0 || reqBody.DepositPercent > 100 {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidDeposit)
+	}
+
 	resBody, err = ctrl.Options.UseCases.Field.UpdateField(ctx.Context(), id, reqBody)
 	if err != nil {
 		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
@@ -199,3 +271,490 @@ func (ctrl *fieldController) DeleteField(ctx *fiber.Ctx) error {
 
 	return helpers.SuccessResponse(ctx, nil)
 }
+
+// GetFieldSchedule godoc
+// @Summary Get field opening hours
+// @Description Get the weekly opening hours of a field. PUBLIC ACCESS - No authentication required. An empty list means the field has no opening hours configured and can be booked at any time.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Param id path string true "Field ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=[]models.FieldScheduleResponse}
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/schedule [get]
+func (ctrl *fieldController) GetFieldSchedule(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	schedule, err := ctrl.Options.UseCases.Field.GetFieldSchedule(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, schedule)
+}
+
+// ReplaceFieldSchedule godoc
+// @Summary Replace field opening hours
+// @Description Replace the complete weekly opening hours of a field. ADMIN ACCESS ONLY. Days are monday to sunday, times are HH:MM in the venue timezone (close_time may be 24:00), and ranges of the same day must not overlap. An empty list removes the opening hours.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param request body models.ReplaceFieldScheduleRequest true "Weekly opening hours"
+// @Success 200 {object} models.BasicResponse{data=[]models.FieldScheduleResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/schedule [put]
+func (ctrl *fieldController) ReplaceFieldSchedule(ctx *fiber.Ctx) error {
+	var reqBody models.ReplaceFieldScheduleRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	schedule, err := ctrl.Options.UseCases.Field.ReplaceFieldSchedule(ctx.Context(), id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, schedule)
+}
+
+// AddFieldSchedule godoc
+// @Summary Add field opening hours
+// @Description Add one opening range to a field's weekly schedule. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param request body models.FieldScheduleRequest true "Opening range"
+// @Success 201 {object} models.BasicResponse{data=models.FieldScheduleResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/schedule [post]
+func (ctrl *fieldController) AddFieldSchedule(ctx *fiber.Ctx) error {
+	var reqBody models.FieldScheduleRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	schedule, err := ctrl.Options.UseCases.Field.AddFieldSchedule(ctx.Context(), id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, schedule)
+}
+
+// DeleteFieldSchedule godoc
+// @Summary Delete field opening hours
+// @Description Remove one opening range from a field's weekly schedule. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param schedule_id path string true "Schedule ID (UUID format)"
+// @Success 200 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/schedule/{schedule_id} [delete]
+func (ctrl *fieldController) DeleteFieldSchedule(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	scheduleID := ctx.Params("schedule_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(scheduleID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	err := ctrl.Options.UseCases.Field.DeleteFieldSchedule(ctx.Context(), id, scheduleID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
+
+// GetFieldBlackouts godoc
+// @Summary Get field blackouts
+// @Description List the maintenance blackouts of a field. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=[]models.FieldBlackoutResponse}
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/blackouts [get]
+func (ctrl *fieldController) GetFieldBlackouts(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	blackouts, err := ctrl.Options.UseCases.Field.GetFieldBlackouts(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, blackouts)
+}
+
+// CreateFieldBlackout godoc
+// @Summary Create field blackout
+// @Description Close a field for maintenance between start_time and end_time. ADMIN ACCESS ONLY. Bookings that collide with the blackout are listed in the response; with cancel_conflicts they are also canceled with a full refund.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param request body models.CreateFieldBlackoutRequest true "Blackout window"
+// @Success 201 {object} models.BasicResponse{data=models.CreateFieldBlackoutResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/blackouts [post]
+func (ctrl *fieldController) CreateFieldBlackout(ctx *fiber.Ctx) error {
+	var reqBody models.CreateFieldBlackoutRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	blackout, err := ctrl.Options.UseCases.Field.CreateFieldBlackout(ctx.Context(), userID, id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, blackout)
+}
+
+// GetBlackoutConflicts godoc
+// @Summary Get bookings colliding with a blackout
+// @Description List the bookings that have not ended yet and overlap a maintenance blackout. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param blackout_id path string true "Blackout ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=models.BlackoutConflictsResponse}
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/blackouts/{blackout_id}/conflicts [get]
+func (ctrl *fieldController) GetBlackoutConflicts(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	blackoutID := ctx.Params("blackout_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(blackoutID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	conflicts, err := ctrl.Options.UseCases.Field.GetBlackoutConflicts(ctx.Context(), id, blackoutID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, conflicts)
+}
+
+// CancelBlackoutConflicts godoc
+// @Summary Cancel bookings colliding with a blackout
+// @Description Cancel every booking that has not ended yet and overlaps a maintenance blackout, refunding everything that was paid. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param blackout_id path string true "Blackout ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=models.BlackoutConflictsResponse}
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/blackouts/{blackout_id}/cancel-conflicts [post]
+func (ctrl *fieldController) CancelBlackoutConflicts(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	blackoutID := ctx.Params("blackout_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(blackoutID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	canceled, err := ctrl.Options.UseCases.Field.CancelBlackoutConflicts(ctx.Context(), userID, id, blackoutID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, canceled)
+}
+
+// DeleteFieldBlackout godoc
+// @Summary Delete field blackout
+// @Description Remove a maintenance blackout, reopening the field for booking. ADMIN ACCESS ONLY. Bookings canceled because of it are not restored.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param blackout_id path string true "Blackout ID (UUID format)"
+// @Success 200 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/blackouts/{blackout_id} [delete]
+func (ctrl *fieldController) DeleteFieldBlackout(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	blackoutID := ctx.Params("blackout_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(blackoutID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	err := ctrl.Options.UseCases.Field.DeleteFieldBlackout(ctx.Context(), id, blackoutID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
+
+// GetPricingRules godoc
+// @Summary Get field pricing rules
+// @Description List the pricing rules of a field. PUBLIC ACCESS - No authentication required. Time not covered by any rule is charged at the field's price_per_hour.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Param id path string true "Field ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=[]models.PricingRuleResponse}
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/pricing-rules [get]
+func (ctrl *fieldController) GetPricingRules(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	rules, err := ctrl.Options.UseCases.Pricing.GetPricingRules(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, rules)
+}
+
+// CreatePricingRule godoc
+// @Summary Create field pricing rule
+// @Description Add a pricing rule to a field. ADMIN ACCESS ONLY. day_type is all, weekday, weekend, holiday or a day name (monday to sunday); start_time and end_time are HH:MM in the venue timezone (end_time may be 24:00, both default to the whole day). Where rules overlap the highest priority wins, then the most specific day type (holiday, day name, weekday/weekend, all).
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param request body models.PricingRuleRequest true "Pricing rule"
+// @Success 201 {object} models.BasicResponse{data=models.PricingRuleResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/pricing-rules [post]
+func (ctrl *fieldController) CreatePricingRule(ctx *fiber.Ctx) error {
+	var reqBody models.PricingRuleRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	rule, err := ctrl.Options.UseCases.Pricing.CreatePricingRule(ctx.Context(), id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, rule)
+}
+
+// UpdatePricingRule godoc
+// @Summary Update field pricing rule
+// @Description Replace a pricing rule of a field. ADMIN ACCESS ONLY. Prices of existing bookings are not changed.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param rule_id path string true "Pricing rule ID (UUID format)"
+// @Param request body models.PricingRuleRequest true "Pricing rule"
+// @Success 200 {object} models.BasicResponse{data=models.PricingRuleResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/pricing-rules/{rule_id} [put]
+func (ctrl *fieldController) UpdatePricingRule(ctx *fiber.Ctx) error {
+	var reqBody models.PricingRuleRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	ruleID := ctx.Params("rule_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(ruleID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	rule, err := ctrl.Options.UseCases.Pricing.UpdatePricingRule(ctx.Context(), id, ruleID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, rule)
+}
+
+// DeletePricingRule godoc
+// @Summary Delete field pricing rule
+// @Description Remove a pricing rule from a field. ADMIN ACCESS ONLY.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Field ID (UUID format)"
+// @Param rule_id path string true "Pricing rule ID (UUID format)"
+// @Success 200 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/pricing-rules/{rule_id} [delete]
+func (ctrl *fieldController) DeletePricingRule(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+	ruleID := ctx.Params("rule_id")
+
+	if !helpers.IsValidUUID(id) || !helpers.IsValidUUID(ruleID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctrl.Options.UseCases.Pricing.DeletePricingRule(ctx.Context(), id, ruleID); err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
+
+// GetQuote godoc
+// @Summary Get price quote
+// @Description Price a booking of the field from start to end without creating it. PUBLIC ACCESS - No authentication required. Every minute is charged at the hourly price of the matching pricing rule, or the field's price_per_hour; the lines show how the amount is made up. Availability is not checked.
+// @Tags Fields
+// @Accept json
+// @Produce json
+// @Param id path string true "Field ID (UUID format)"
+// @Param start query string true "Booking start (RFC3339)"
+// @Param end query string true "Booking end (RFC3339)"
+// @Success 200 {object} models.BasicResponse{data=models.PriceQuoteResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /fields/{id}/quote [get]
+func (ctrl *fieldController) GetQuote(ctx *fiber.Ctx) error {
+	var reqQuery models.PriceQuoteRequest
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	quote, err := ctrl.Options.UseCases.Pricing.GetQuote(ctx.Context(), id, reqQuery)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, quote)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type holidayController struct {
+	Options Options
+}
+
+type HolidayInterface interface {
+	GetHolidays(ctx *fiber.Ctx) error
+	CreateHoliday(ctx *fiber.Ctx) error
+	ImportHolidays(ctx *fiber.Ctx) error
+	DeleteHoliday(ctx *fiber.Ctx) error
+}
+
+// GetHolidays godoc
+// @Summary Get holidays
+// @Description List the dates on which the whole venue is closed. PUBLIC ACCESS - No authentication required.
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD)"
+// @Success 200 {object} models.BasicResponse{data=[]models.HolidayResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Router /holidays [get]
+func (ctrl *holidayController) GetHolidays(ctx *fiber.Ctx) error {
+	var reqQuery models.HolidayListRequest
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	holidays, err := ctrl.Options.UseCases.Holiday.GetHolidays(ctx.Context(), reqQuery)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, holidays)
+}
+
+// CreateHoliday godoc
+// @Summary Create holiday
+// @Description Close every field for a whole day in the venue timezone. ADMIN ACCESS ONLY. A date that already is a holiday is renamed.
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.HolidayRequest true "Holiday"
+// @Success 201 {object} models.BasicResponse{data=models.HolidayResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Router /holidays [post]
+func (ctrl *holidayController) CreateHoliday(ctx *fiber.Ctx) error {
+	var reqBody models.HolidayRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	holiday, err := ctrl.Options.UseCases.Holiday.CreateHoliday(ctx.Context(), reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, holiday)
+}
+
+// ImportHolidays godoc
+// @Summary Import holidays from iCalendar
+// @Description Add the dates of every event in an iCalendar (.ics) file as holidays named after the event. ADMIN ACCESS ONLY. Send the file as multipart form field "file" or as the raw text/calendar body. Dates that already are holidays are renamed.
+// @Tags Holidays
+// @Accept multipart/form-data
+// @Accept text/calendar
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "iCalendar file"
+// @Success 201 {object} models.BasicResponse{data=models.ImportHolidaysResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Router /holidays/import [post]
+func (ctrl *holidayController) ImportHolidays(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	var calendar io.Reader
+	if header, err := ctx.FormFile("file"); err == nil {
+		file, err := header.Open()
+		if err != nil {
+			return helpers.BadRequestResponse(ctx, constants.ErrCalendarRequired)
+		}
+		defer file.Close()
+		calendar = file
+	} else if len(ctx.Body()) > 0 {
+		calendar = bytes.NewReader(ctx.Body())
+	} else {
+		return helpers.BadRequestResponse(ctx, constants.ErrCalendarRequired)
+	}
+
+	imported, err := ctrl.Options.UseCases.Holiday.ImportHolidays(ctx.Context(), calendar)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, imported)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete holiday
+// @Description Reopen the venue on a holiday. ADMIN ACCESS ONLY.
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID (UUID format)"
+// @Success 200 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /holidays/{id} [delete]
+func (ctrl *holidayController) DeleteHoliday(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctrl.Options.UseCases.Holiday.DeleteHoliday(ctx.Context(), id); err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
@@ -6,11 +6,17 @@ import (
 )
 
 type Main struct {
-	Auth    AuthInterface
-	User    UserInterface
-	Field   FieldInterface
-	Booking BookingInterface
-	Payment PaymentInterface
+	Auth         AuthInterface
+	User         UserInterface
+	Field        FieldInterface
+	Booking      BookingInterface
+	Payment      PaymentInterface
+	Holiday      HolidayInterface
+	Voucher      VoucherInterface
+	Waitlist     WaitlistInterface
+	Notification NotificationInterface
+	Cart         CartInterface
+	SplitPayment SplitPaymentInterface
 }
 
 type controller struct {
@@ -26,11 +32,17 @@ func Init(opts Options) *Main {
 	ctrl := &controller{opts}
 
 	m := &Main{
-		Auth:    (*authController)(ctrl),
-		User:    (*userController)(ctrl),
-		Field:   (*fieldController)(ctrl),
-		Booking: (*bookingController)(ctrl),
-		Payment: (*paymentController)(ctrl),
+		Auth:         (*authController)(ctrl),
+		User:         (*userController)(ctrl),
+		Field:        (*fieldController)(ctrl),
+		Booking:      (*bookingController)(ctrl),
+		Payment:      (*paymentController)(ctrl),
+		Holiday:      (*holidayController)(ctrl),
+		Voucher:      (*voucherController)(ctrl),
+		Waitlist:     (*waitlistController)(ctrl),
+		Notification: (*notificationController)(ctrl),
+		Cart:         (*cartController)(ctrl),
+		SplitPayment: (*splitPaymentController)(ctrl),
 	}
 
 	return m
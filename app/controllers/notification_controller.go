@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type notificationController struct {
+	Options Options
+}
+
+type NotificationInterface interface {
+	GetNotifications(ctx *fiber.Ctx) error
+	MarkNotificationRead(ctx *fiber.Ctx) error
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get a page of the authenticated user's notifications, latest first.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Items per page (default 20, max 100)"
+// @Param unread query bool false "Only notifications not read yet"
+// @Success 200 {object} models.ResponseWithPaginate{data=[]models.NotificationResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Router /notifications [get]
+func (ctrl *notificationController) GetNotifications(ctx *fiber.Ctx) error {
+	var reqQuery models.NotificationListRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	if err := ctx.QueryParser(&reqQuery); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	notifications, pagination, err := ctrl.Options.UseCases.Notification.GetNotifications(ctx.Context(), userID, reqQuery)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponseWithPagination(ctx, notifications, pagination)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Description Mark one of the authenticated user's notifications as read.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 200 {object} models.BasicResponse
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /notifications/{id}/read [post]
+func (ctrl *notificationController) MarkNotificationRead(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	err := ctrl.Options.UseCases.Notification.MarkNotificationRead(ctx.Context(), helpers.GetUserIDFromContext(ctx), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
@@ -19,6 +19,10 @@ type PaymentInterface interface {
 	GetPaymentByBookingID(ctx *fiber.Ctx) error
 	CreatePaymentTransaction(ctx *fiber.Ctx) error
 	HandlePaymentNotification(ctx *fiber.Ctx) error
+	GetFakeTransaction(ctx *fiber.Ctx) error
+	SimulatePayment(ctx *fiber.Ctx) error
+	RefundPayment(ctx *fiber.Ctx) error
+	RecordCashPayment(ctx *fiber.Ctx) error
 }
 
 // CreatePaymentTransaction godoc
@@ -28,10 +32,12 @@ type PaymentInterface interface {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
 // @Param booking_id path string true "Booking ID"
 // @Success 200 {object} models.BasicResponse{data=models.PaymentTransactionResponse}
 // @Failure 400 {object} models.BasicResponse
 // @Failure 403 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
 // @Router /payments/{booking_id}/transaction [post]
 func (c *paymentController) CreatePaymentTransaction(ctx *fiber.Ctx) error {
 	bookingID := ctx.Params("booking_id")
@@ -63,12 +69,14 @@ func (c *paymentController) CreatePaymentTransaction(ctx *fiber.Ctx) error {
 
 // HandlePaymentNotification godoc
 // @Summary Handle payment notification webhook
-// @Description Webhook endpoint for Midtrans payment notifications
+// @Description Webhook endpoint for Midtrans payment notifications. The signature_key and gross_amount are verified, and duplicated or replayed notifications are acknowledged without being applied again.
 // @Tags Payments
 // @Accept json
 // @Produce json
 // @Param payload body map[string]interface{} true "Midtrans notification payload"
 // @Success 200 {object} models.BasicResponse
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
 // @Router /payments/notification [post]
 func (c *paymentController) HandlePaymentNotification(ctx *fiber.Ctx) error {
 	var payload map[string]interface{}
@@ -132,7 +140,7 @@ func (c *paymentController) ProcessPayment(ctx *fiber.Ctx) error {
 		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
 	}
 
-	resBody, err = c.Options.UseCases.Payment.ProcessPayment(ctx.Context(), reqBody.BookingID.String(), reqBody)
+	resBody, err = c.Options.UseCases.Payment.ProcessPayment(ctx.Context(), reqBody.BookingID.String(), helpers.GetActorFromContext(ctx), reqBody)
 	if err != nil {
 		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
 	}
@@ -178,3 +186,140 @@ func (c *paymentController) GetPaymentByBookingID(ctx *fiber.Ctx) error {
 
 	return helpers.SuccessResponse(ctx, payment)
 }
+
+// GetFakeTransaction godoc
+// @Summary Get fake gateway transaction
+// @Description Show a transaction of the offline fake payment gateway. Only available when PAYMENT_GATEWAY=fake.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /payments/fake/{order_id} [get]
+func (c *paymentController) GetFakeTransaction(ctx *fiber.Ctx) error {
+	transaction, err := c.Options.UseCases.Payment.GetFakeTransaction(ctx.Context(), ctx.Params("order_id"))
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, transaction)
+}
+
+// SimulatePayment godoc
+// @Summary Simulate fake gateway payment
+// @Description Move a fake gateway transaction to a new status (settlement, capture, deny, cancel, expire, failure) and process the resulting notification. Only available when PAYMENT_GATEWAY=fake.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Param request body models.SimulatePaymentRequest true "Simulated status"
+// @Success 200 {object} models.BasicResponse
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /payments/fake/{order_id} [post]
+func (c *paymentController) SimulatePayment(ctx *fiber.Ctx) error {
+	var reqBody models.SimulatePaymentRequest
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if reqBody.TransactionStatus == "" {
+		return helpers.BadRequestResponse(ctx, "Transaction status is required")
+	}
+
+	err := c.Options.UseCases.Payment.SimulatePayment(ctx.Context(), ctx.Params("order_id"), reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, nil)
+}
+
+// RefundPayment godoc
+// @Summary Refund a payment
+// @Description Refund part or all of a booking's payment through the payment gateway (admin only). Omit amount to refund everything not refunded yet. The booking status is not changed.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param booking_id path string true "Booking ID"
+// @Param request body models.RefundRequest true "Refund data"
+// @Success 200 {object} models.BasicResponse{data=models.PaymentResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /payments/{booking_id}/refunds [post]
+func (c *paymentController) RefundPayment(ctx *fiber.Ctx) error {
+	var reqBody models.RefundRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := c.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	bookingID := ctx.Params("booking_id")
+
+	if !helpers.IsValidUUID(bookingID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&reqBody); err != nil {
+			return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+		}
+	}
+
+	payment, err := c.Options.UseCases.Payment.RefundPayment(ctx.Context(), bookingID, userID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, payment)
+}
+
+// RecordCashPayment godoc
+// @Summary Record a cash payment
+// @Description Record cash taken at the counter for a booking (admin only), typically the balance left after a deposit. Omit amount to pay everything still due. Open gateway transactions of the booking are expired; the booking is paid once nothing is due, or partially paid when at least its deposit is.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param booking_id path string true "Booking ID"
+// @Param request body models.CashPaymentRequest false "Cash payment data"
+// @Success 200 {object} models.BasicResponse{data=models.PaymentResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /payments/{booking_id}/cash [post]
+func (c *paymentController) RecordCashPayment(ctx *fiber.Ctx) error {
+	var reqBody models.CashPaymentRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := c.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	bookingID := ctx.Params("booking_id")
+
+	if !helpers.IsValidUUID(bookingID) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&reqBody); err != nil {
+			return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+		}
+	}
+
+	payment, err := c.Options.UseCases.Payment.RecordCashPayment(ctx.Context(), bookingID, userID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, payment)
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type splitPaymentController struct {
+	Options Options
+}
+
+type SplitPaymentInterface interface {
+	SplitPayment(ctx *fiber.Ctx) error
+	GetSplitPayment(ctx *fiber.Ctx) error
+	CoverRemainder(ctx *fiber.Ctx) error
+	GetUserShares(ctx *fiber.Ctx) error
+	PayShare(ctx *fiber.Ctx) error
+}
+
+// SplitPayment godoc
+// @Summary Split booking payment
+// @Description Split the payment of an unpaid booking evenly between its owner and 1 to 9 invited emails (owner only); the owner's share takes what does not divide evenly. The booking stays held until the deadline (default SPLIT_PAYMENT_TTL, at most until the booking starts). Each participant pays their share through POST /payments/shares/{id}/transaction, and the booking is paid once every share is paid or the owner covers the rest. Unpaid by the deadline, the booking is canceled and the paid shares are refunded. Invited emails registered with an account get a notification.
+// @Tags Split Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Param request body models.SplitPaymentRequest true "Participants and deadline"
+// @Success 201 {object} models.BasicResponse{data=models.SplitPaymentResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/split [post]
+func (ctrl *splitPaymentController) SplitPayment(ctx *fiber.Ctx) error {
+	var reqBody models.SplitPaymentRequest
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	if booking.UserID.String() != helpers.GetUserIDFromContext(ctx) {
+		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
+	}
+
+	split, err := ctrl.Options.UseCases.SplitPayment.SplitPayment(ctx.Context(), id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, split)
+}
+
+// GetSplitPayment godoc
+// @Summary Get split payment
+// @Description Get the split payment of a booking with the status of each share (owner or admin).
+// @Tags Split Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.SplitPaymentResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/split [get]
+func (ctrl *splitPaymentController) GetSplitPayment(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	userRole := helpers.GetUserRoleFromContext(ctx)
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	if userRole != constants.ROLE_ADMIN && booking.UserID.String() != userID {
+		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
+	}
+
+	split, err := ctrl.Options.UseCases.SplitPayment.GetSplitPayment(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, split)
+}
+
+// CoverRemainder godoc
+// @Summary Cover unpaid shares
+// @Description Create a Midtrans transaction for the booking owner paying whatever the shares have not paid yet, before the deadline (owner only). Open transactions of the shares are expired; once it settles the booking is paid and the unpaid shares become covered.
+// @Tags Split Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param id path string true "Booking ID"
+// @Success 200 {object} models.BasicResponse{data=models.PaymentTransactionResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /bookings/{id}/split/cover [post]
+func (ctrl *splitPaymentController) CoverRemainder(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	booking, err := ctrl.Options.UseCases.Booking.GetBookingByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	if booking.UserID.String() != helpers.GetUserIDFromContext(ctx) {
+		return helpers.ForbiddenResponse(ctx, constants.ErrUnauthorizedAccess)
+	}
+
+	transaction, err := ctrl.Options.UseCases.SplitPayment.CoverRemainder(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, transaction)
+}
+
+// GetUserShares godoc
+// @Summary Get user payment shares
+// @Description List the shares of split payments the authenticated user was invited to pay, matched by their email, latest first.
+// @Tags Split Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse{data=[]models.PaymentShareResponse}
+// @Failure 401 {object} models.BasicResponse
+// @Router /users/shares [get]
+func (ctrl *splitPaymentController) GetUserShares(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	shares, err := ctrl.Options.UseCases.SplitPayment.GetUserShares(ctx.Context(), userID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, shares)
+}
+
+// PayShare godoc
+// @Summary Pay payment share
+// @Description Create a Midtrans transaction for the authenticated user paying their share of a split payment before its deadline. A transaction opened for the share before is expired.
+// @Tags Split Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried with the same key"
+// @Param id path string true "Share ID"
+// @Success 200 {object} models.BasicResponse{data=models.PaymentTransactionResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /payments/shares/{id}/transaction [post]
+func (ctrl *splitPaymentController) PayShare(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	transaction, err := ctrl.Options.UseCases.SplitPayment.PayShare(ctx.Context(), helpers.GetUserIDFromContext(ctx), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, transaction)
+}
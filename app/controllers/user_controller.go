@@ -3,6 +3,7 @@ package controllers
 import (
 	"take-home-test/app/constants"
 	"take-home-test/app/helpers"
+	"take-home-test/app/models"
 	"take-home-test/pkg/customerror"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,7 +15,9 @@ type userController struct {
 
 type UserInterface interface {
 	GetProfile(ctx *fiber.Ctx) error
+	GetCredits(ctx *fiber.Ctx) error
 	GetUserByID(ctx *fiber.Ctx) error
+	UpdateUserRole(ctx *fiber.Ctx) error
 }
 
 // GetProfile godoc
@@ -42,6 +45,30 @@ func (c *userController) GetProfile(ctx *fiber.Ctx) error {
 	return helpers.SuccessResponse(ctx, user)
 }
 
+// GetCredits godoc
+// @Summary Get user credits
+// @Description Get the authenticated user's credit balance and its history, e.g. the difference kept when a paid booking was rescheduled to a cheaper slot.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse{data=models.CreditBalanceResponse}
+// @Failure 401 {object} models.BasicResponse
+// @Router /users/credits [get]
+func (c *userController) GetCredits(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	credits, err := c.Options.UseCases.User.GetUserCredits(ctx.Context(), userID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, credits)
+}
+
 // GetUserByID godoc
 // @Summary Get user by ID
 // @Description Get user details by ID (Admin only)
@@ -73,3 +100,47 @@ func (c *userController) GetUserByID(ctx *fiber.Ctx) error {
 
 	return helpers.SuccessResponse(ctx, user) // ✅ Gunakan helper convenience
 }
+
+// UpdateUserRole godoc
+// @Summary Update user role
+// @Description Promote a user to admin or demote an admin to user. ADMIN ACCESS ONLY - The user's sessions are revoked so the new role applies immediately.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body models.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} models.BasicResponse{data=models.UserResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /users/{id}/role [patch]
+func (c *userController) UpdateUserRole(ctx *fiber.Ctx) error {
+	var reqBody models.UpdateUserRoleRequest
+
+	currentUserID := helpers.GetUserIDFromContext(ctx)
+	if err := c.Options.UseCases.Validate.IsAdminUser(ctx.Context(), currentUserID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	if err := helpers.ValidateUpdateUserRoleRequest(reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, err.Error())
+	}
+
+	user, err := c.Options.UseCases.User.UpdateUserRole(ctx.Context(), currentUserID, id, reqBody.Role)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, user)
+}
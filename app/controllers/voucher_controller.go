@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type voucherController struct {
+	Options Options
+}
+
+type VoucherInterface interface {
+	GetVouchers(ctx *fiber.Ctx) error
+	GetVoucherByID(ctx *fiber.Ctx) error
+	CreateVoucher(ctx *fiber.Ctx) error
+	UpdateVoucher(ctx *fiber.Ctx) error
+}
+
+// GetVouchers godoc
+// @Summary Get vouchers
+// @Description List every voucher with its usage. ADMIN ACCESS ONLY.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse{data=[]models.VoucherResponse}
+// @Failure 403 {object} models.BasicResponse
+// @Router /vouchers [get]
+func (ctrl *voucherController) GetVouchers(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	vouchers, err := ctrl.Options.UseCases.Voucher.GetVouchers(ctx.Context())
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, vouchers)
+}
+
+// GetVoucherByID godoc
+// @Summary Get voucher by ID
+// @Description Get a voucher with its usage. ADMIN ACCESS ONLY.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Voucher ID (UUID format)"
+// @Success 200 {object} models.BasicResponse{data=models.VoucherResponse}
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /vouchers/{id} [get]
+func (ctrl *voucherController) GetVoucherByID(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	voucher, err := ctrl.Options.UseCases.Voucher.GetVoucherByID(ctx.Context(), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, voucher)
+}
+
+// CreateVoucher godoc
+// @Summary Create voucher
+// @Description Create a promo code. ADMIN ACCESS ONLY. A percentage voucher takes discount_value percent off (capped by max_discount), a fixed voucher takes discount_value off. Omitted limits, validity bounds and field_ids mean unlimited; codes are case-insensitive.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.VoucherRequest true "Voucher"
+// @Success 201 {object} models.BasicResponse{data=models.VoucherResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /vouchers [post]
+func (ctrl *voucherController) CreateVoucher(ctx *fiber.Ctx) error {
+	var reqBody models.VoucherRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	voucher, err := ctrl.Options.UseCases.Voucher.CreateVoucher(ctx.Context(), reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, voucher)
+}
+
+// UpdateVoucher godoc
+// @Summary Update voucher
+// @Description Replace the settings of a voucher; set active to false to withdraw it. ADMIN ACCESS ONLY. Discounts already given are not changed, and usage_limit cannot go below used_count.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Voucher ID (UUID format)"
+// @Param request body models.VoucherRequest true "Voucher"
+// @Success 200 {object} models.BasicResponse{data=models.VoucherResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 403 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /vouchers/{id} [put]
+func (ctrl *voucherController) UpdateVoucher(ctx *fiber.Ctx) error {
+	var reqBody models.VoucherRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if err := ctrl.Options.UseCases.Validate.IsAdminUser(ctx.Context(), userID); err != nil {
+		return helpers.ForbiddenResponse(ctx, constants.ErrAdminAccessRequired)
+	}
+
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	voucher, err := ctrl.Options.UseCases.Voucher.UpdateVoucher(ctx.Context(), id, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, voucher)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type waitlistController struct {
+	Options Options
+}
+
+type WaitlistInterface interface {
+	JoinWaitlist(ctx *fiber.Ctx) error
+	GetUserWaitlist(ctx *fiber.Ctx) error
+	LeaveWaitlist(ctx *fiber.Ctx) error
+	ConfirmOffer(ctx *fiber.Ctx) error
+}
+
+// JoinWaitlist godoc
+// @Summary Join waitlist
+// @Description Wait for a taken slot of a field. When a booking overlapping the slot is canceled or expires, the oldest waiting user whose whole slot became free gets it held for WAITLIST_OFFER_TTL and a notification. A slot that is still free must be booked directly.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WaitlistRequest true "Slot to wait for"
+// @Success 201 {object} models.BasicResponse{data=models.WaitlistEntryResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 401 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Failure 409 {object} models.BasicResponse
+// @Router /waitlist [post]
+func (ctrl *waitlistController) JoinWaitlist(ctx *fiber.Ctx) error {
+	var reqBody models.WaitlistRequest
+
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	if err := ctx.BodyParser(&reqBody); err != nil {
+		return helpers.BadRequestResponse(ctx, constants.ErrBadRequest)
+	}
+
+	entry, err := ctrl.Options.UseCases.Waitlist.JoinWaitlist(ctx.Context(), userID, reqBody)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.CreatedResponse(ctx, entry)
+}
+
+// GetUserWaitlist godoc
+// @Summary Get user waitlist
+// @Description List the authenticated user's waitlist entries, latest first. An offered entry shows the held booking and until when it can be confirmed.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BasicResponse{data=[]models.WaitlistEntryResponse}
+// @Failure 401 {object} models.BasicResponse
+// @Router /waitlist [get]
+func (ctrl *waitlistController) GetUserWaitlist(ctx *fiber.Ctx) error {
+	userID := helpers.GetUserIDFromContext(ctx)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(ctx, constants.ErrMissingToken)
+	}
+
+	entries, err := ctrl.Options.UseCases.Waitlist.GetUserWaitlist(ctx.Context(), userID)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, entries)
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave waitlist
+// @Description Leave the waitlist. An open offer is declined and the slot passes to the next user waiting for it.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} models.BasicResponse{data=models.WaitlistEntryResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /waitlist/{id} [delete]
+func (ctrl *waitlistController) LeaveWaitlist(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	entry, err := ctrl.Options.UseCases.Waitlist.LeaveWaitlist(ctx.Context(), helpers.GetUserIDFromContext(ctx), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, entry)
+}
+
+// ConfirmOffer godoc
+// @Summary Confirm waitlist offer
+// @Description Turn the slot held for a waitlist entry into a regular pending booking at the current price. It is then paid like any new booking within BOOKING_HOLD_TTL.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} models.BasicResponse{data=models.BookingResponse}
+// @Failure 400 {object} models.BasicResponse
+// @Failure 404 {object} models.BasicResponse
+// @Router /waitlist/{id}/confirm [post]
+func (ctrl *waitlistController) ConfirmOffer(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if !helpers.IsValidUUID(id) {
+		return helpers.BadRequestResponse(ctx, constants.ErrInvalidUUID)
+	}
+
+	booking, err := ctrl.Options.UseCases.Waitlist.ConfirmOffer(ctx.Context(), helpers.GetUserIDFromContext(ctx), id)
+	if err != nil {
+		return helpers.StandardResponse(ctx, customerror.GetStatusCode(err), []string{err.Error()}, nil, nil)
+	}
+
+	return helpers.SuccessResponse(ctx, booking)
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -88,6 +91,43 @@ func GetUserRoleFromContext(c *fiber.Ctx) string {
 	return ""
 }
 
+// GetActorFromContext gets the authenticated user as the actor of a status
+// change, sourced as admin or user by the token role
+func GetActorFromContext(c *fiber.Ctx) models.Actor {
+	actor := models.Actor{Source: constants.STATUS_SOURCE_USER}
+	if GetUserRoleFromContext(c) == constants.ROLE_ADMIN {
+		actor.Source = constants.STATUS_SOURCE_ADMIN
+	}
+	if id, err := uuid.Parse(GetUserIDFromContext(c)); err == nil {
+		actor.ID = &id
+	}
+	return actor
+}
+
+// GetTokenIDFromContext gets the access token jti from Fiber context
+func GetTokenIDFromContext(c *fiber.Ctx) string {
+	if tokenID, ok := c.Locals("jti").(string); ok {
+		return tokenID
+	}
+	return ""
+}
+
+// GetSessionIDFromContext gets the access token session ID from Fiber context
+func GetSessionIDFromContext(c *fiber.Ctx) string {
+	if sessionID, ok := c.Locals("sessionID").(string); ok {
+		return sessionID
+	}
+	return ""
+}
+
+// GetTokenExpiresAtFromContext gets the access token expiry from Fiber context
+func GetTokenExpiresAtFromContext(c *fiber.Ctx) time.Time {
+	if expiresAt, ok := c.Locals("tokenExpiresAt").(time.Time); ok {
+		return expiresAt
+	}
+	return time.Now()
+}
+
 // Parse query parameters with defaults
 func ParseQueryInt(c *fiber.Ctx, key string, defaultValue int) int {
 	value := c.Query(key)
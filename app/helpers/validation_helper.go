@@ -24,7 +24,11 @@ func ValidateRegisterRequest(req models.RegisterRequest) error {
 		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidPassword)
 	}
 
-	if req.Role != "" && req.Role != constants.ROLE_USER && req.Role != constants.ROLE_ADMIN {
+	return nil
+}
+
+func ValidateUpdateUserRoleRequest(req models.UpdateUserRoleRequest) error {
+	if !Contains(constants.ValidUserRoles, req.Role) {
 		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRole)
 	}
 
@@ -7,14 +7,22 @@ import (
 )
 
 type Booking struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID    uuid.UUID `json:"user_id"`
-	FieldID   uuid.UUID `json:"field_id"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	Status    string    `json:"status" gorm:"default:'pending'"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        uuid.UUID  `json:"user_id"`
+	FieldID       uuid.UUID  `json:"field_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	Status        string     `json:"status" gorm:"default:'pending'"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at"`
+	Channel       string     `json:"channel" gorm:"default:'online'"`
+	CustomerName  string     `json:"customer_name"`
+	CustomerPhone string     `json:"customer_phone"`
+	CreatedBy     *uuid.UUID `json:"created_by"`
+	SeriesID      *uuid.UUID `json:"series_id"`
+	OrderID       *uuid.UUID `json:"order_id"`
+	Price         int        `json:"price"` // price of the slot, before any discount
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 func (Booking) TableName() string {
@@ -22,17 +30,161 @@ func (Booking) TableName() string {
 }
 
 type BookingResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	FieldID   uuid.UUID `json:"field_id"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	FieldID       uuid.UUID  `json:"field_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	Status        string     `json:"status"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty"`
+	Channel       string     `json:"channel"`
+	CustomerName  string     `json:"customer_name,omitempty"`
+	CustomerPhone string     `json:"customer_phone,omitempty"`
+	SeriesID      *uuid.UUID `json:"series_id,omitempty"`
+	OrderID       *uuid.UUID `json:"order_id,omitempty"`
+	Price         int        `json:"price"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// BookingDetailResponse is a booking together with its customer, field and
+// payment, as shown to admins.
+type BookingDetailResponse struct {
+	BookingResponse
+	User    *UserResponse    `json:"user,omitempty"`
+	Field   *FieldResponse   `json:"field,omitempty"`
+	Payment *PaymentResponse `json:"payment,omitempty"`
+
+	History []StatusHistoryResponse `json:"history,omitempty"`
+}
+
+type CancelBookingRequest struct {
+	Reason string `json:"reason"`
+}
+
+type CancelBookingResponse struct {
+	Booking       BookingResponse `json:"booking"`
+	RefundPercent int             `json:"refund_percent"`
+	RefundAmount  int             `json:"refund_amount"`
+}
+
+// WalkInBookingRequest books a field for a customer at the counter. user_id
+// links the booking to an existing account; without it the booking is kept
+// under the admin and customer_name is required. A payment_method records
+// the booking as paid in full right away.
+type WalkInBookingRequest struct {
+	FieldID       uuid.UUID  `json:"field_id" validate:"required"`
+	StartTime     time.Time  `json:"start_time" validate:"required"`
+	EndTime       time.Time  `json:"end_time" validate:"required"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	CustomerName  string     `json:"customer_name,omitempty" example:"Budi"`
+	CustomerPhone string     `json:"customer_phone,omitempty" example:"081234567890"`
+	VoucherCode   string     `json:"voucher_code,omitempty"`
+	PaymentMethod string     `json:"payment_method,omitempty" example:"cash"`
+}
+
+type RescheduleBookingRequest struct {
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// RescheduleBookingResponse is the moved booking and how its payment
+// changed: a higher price leaves AmountDue to pay, charged through
+// Transaction once the booking was already paid, and a lower price than
+// what was paid is kept as Credit.
+type RescheduleBookingResponse struct {
+	Booking         BookingResponse             `json:"booking"`
+	PriceDifference int                         `json:"price_difference" example:"25000"`
+	AmountDue       int                         `json:"amount_due" example:"25000"`
+	Credit          int                         `json:"credit"`
+	Transaction     *PaymentTransactionResponse `json:"transaction,omitempty"`
 }
 
 type CreateBookingRequest struct {
+	FieldID     uuid.UUID `json:"field_id" validate:"required"`
+	StartTime   time.Time `json:"start_time" validate:"required"`
+	EndTime     time.Time `json:"end_time" validate:"required"`
+	VoucherCode string    `json:"voucher_code,omitempty" example:"WEEKEND20"`
+}
+
+// BookingSeries is a booking repeated weekly or every other week. Its
+// bookings share a single payment.
+type BookingSeries struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID `json:"user_id"`
+	FieldID     uuid.UUID `json:"field_id"`
+	Frequency   string    `json:"frequency"`
+	Occurrences int       `json:"occurrences"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (BookingSeries) TableName() string {
+	return "booking_series"
+}
+
+// RecurringBookingRequest repeats the slot from start_time to end_time
+// weekly or biweekly, either count times or until the given date (in the
+// venue timezone, inclusive). With both, the series stops at whichever comes
+// first.
+type RecurringBookingRequest struct {
 	FieldID   uuid.UUID `json:"field_id" validate:"required"`
 	StartTime time.Time `json:"start_time" validate:"required"`
 	EndTime   time.Time `json:"end_time" validate:"required"`
+	Frequency string    `json:"frequency" validate:"required" example:"weekly"`
+	Count     int       `json:"count,omitempty" example:"12"`
+	Until     string    `json:"until,omitempty" example:"2025-12-31"`
+}
+
+// BookingConflict is an occurrence of a recurring booking that cannot be
+// booked, and why.
+type BookingConflict struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+type BookingConflictsResponse struct {
+	Conflicts []BookingConflict `json:"conflicts"`
+}
+
+// BookingSeriesResponse is a created series; amount is what its single
+// payment asks for.
+type BookingSeriesResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	FieldID     uuid.UUID         `json:"field_id"`
+	Frequency   string            `json:"frequency"`
+	Occurrences int               `json:"occurrences"`
+	Amount      int               `json:"amount"`
+	PaymentID   uuid.UUID         `json:"payment_id"`
+	Bookings    []BookingResponse `json:"bookings"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// BookingListRequest filters a booking list. status takes a comma-separated
+// list; from and to accept RFC3339 or a date in the venue timezone and match
+// bookings overlapping the range.
+type BookingListRequest struct {
+	PageRequest
+	Status  string `query:"status"`
+	FieldID string `query:"field_id"`
+	From    string `query:"from"`
+	To      string `query:"to"`
+}
+
+// AdminBookingListRequest is a BookingListRequest across all users. q
+// searches the customer's name, email and phone and the field name.
+type AdminBookingListRequest struct {
+	BookingListRequest
+	UserID string `query:"user_id"`
+	Search string `query:"q"`
+}
+
+// BookingFilter is a parsed BookingListRequest; empty values match every
+// booking.
+type BookingFilter struct {
+	UserID   string
+	FieldID  string
+	Statuses []string
+	From     *time.Time
+	To       *time.Time
+	Search   string
 }
@@ -7,12 +7,13 @@ import (
 )
 
 type Field struct {
-	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name         string    `json:"name"`
-	PricePerHour int       `json:"price_per_hour"`
-	Location     string    `json:"location"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name           string    `json:"name"`
+	PricePerHour   int       `json:"price_per_hour"`
+	DepositPercent int       `json:"deposit_percent"` // share of a booking's amount paid up front; 0 asks for the full amount
+	Location       string    `json:"location"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 func (Field) TableName() string {
@@ -20,22 +21,136 @@ func (Field) TableName() string {
 }
 
 type FieldResponse struct {
-	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	PricePerHour int       `json:"price_per_hour"`
-	Location     string    `json:"location"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	PricePerHour   int       `json:"price_per_hour"`
+	DepositPercent int       `json:"deposit_percent"`
+	Location       string    `json:"location"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type CreateFieldRequest struct {
-	Name         string `json:"name" validate:"required"`
-	PricePerHour int    `json:"price_per_hour" validate:"required,min=0"`
-	Location     string `json:"location" validate:"required"`
+	Name           string `json:"name" validate:"required"`
+	PricePerHour   int    `json:"price_per_hour" validate:"required,min=0"`
+	DepositPercent int    `json:"deposit_percent" validate:"min=0,max=100" example:"30"`
+	Location       string `json:"location" validate:"required"`
 }
 
 type UpdateFieldRequest struct {
-	Name         string `json:"name" validate:"required"`
-	PricePerHour int    `json:"price_per_hour" validate:"required,min=0"`
-	Location     string `json:"location" validate:"required"`
+	Name           string `json:"name" validate:"required"`
+	PricePerHour   int    `json:"price_per_hour" validate:"required,min=0"`
+	DepositPercent int    `json:"deposit_percent" validate:"min=0,max=100" example:"30"`
+	Location       string `json:"location" validate:"required"`
+}
+
+// FieldFilter narrows a field list; empty values match every field.
+type FieldFilter struct {
+	Name     string `query:"name"`
+	Location string `query:"location"`
+	MinPrice *int   `query:"min_price"`
+	MaxPrice *int   `query:"max_price"`
+}
+
+type FieldListRequest struct {
+	PageRequest
+	FieldFilter
+}
+
+type FieldAvailabilityRequest struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+	Slot string `query:"slot"`
+}
+
+type AvailabilitySlot struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Status    string    `json:"status"`
+}
+
+type FieldAvailabilityResponse struct {
+	FieldID uuid.UUID          `json:"field_id"`
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Slot    string             `json:"slot"`
+	Slots   []AvailabilitySlot `json:"slots"`
+}
+
+// FieldSchedule is one opening range of a field on a day of the week. Times
+// are "HH:MM" in the venue timezone; "24:00" closes at midnight.
+type FieldSchedule struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FieldID   uuid.UUID `json:"field_id"`
+	DayOfWeek string    `json:"day_of_week"`
+	OpenTime  string    `json:"open_time"`
+	CloseTime string    `json:"close_time"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (FieldSchedule) TableName() string {
+	return "field_schedules"
+}
+
+type FieldScheduleRequest struct {
+	DayOfWeek string `json:"day_of_week" example:"monday"`
+	OpenTime  string `json:"open_time" example:"08:00"`
+	CloseTime string `json:"close_time" example:"22:00"`
+}
+
+type ReplaceFieldScheduleRequest struct {
+	Ranges []FieldScheduleRequest `json:"ranges"`
+}
+
+type FieldScheduleResponse struct {
+	ID        uuid.UUID `json:"id"`
+	DayOfWeek string    `json:"day_of_week"`
+	OpenTime  string    `json:"open_time"`
+	CloseTime string    `json:"close_time"`
+}
+
+// FieldBlackout is a maintenance window during which the field cannot be
+// booked.
+type FieldBlackout struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FieldID   uuid.UUID `json:"field_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (FieldBlackout) TableName() string {
+	return "field_blackouts"
+}
+
+type CreateFieldBlackoutRequest struct {
+	StartTime       time.Time `json:"start_time" validate:"required"`
+	EndTime         time.Time `json:"end_time" validate:"required"`
+	Reason          string    `json:"reason" example:"Turf replacement"`
+	CancelConflicts bool      `json:"cancel_conflicts"`
+}
+
+type FieldBlackoutResponse struct {
+	ID        uuid.UUID `json:"id"`
+	FieldID   uuid.UUID `json:"field_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlackoutConflictsResponse lists the bookings that collide with a blackout
+// and, when they were canceled, how many of them.
+type BlackoutConflictsResponse struct {
+	Conflicts []BookingResponse `json:"conflicts"`
+	Canceled  int               `json:"canceled"`
+}
+
+type CreateFieldBlackoutResponse struct {
+	Blackout  FieldBlackoutResponse `json:"blackout"`
+	Conflicts []BookingResponse     `json:"conflicts"`
+	Canceled  int                   `json:"canceled"`
 }
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holiday closes every field for a whole day in the venue timezone. Date is
+// stored as midnight UTC of that day.
+type Holiday struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Date      time.Time `json:"date" gorm:"type:date"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
+
+type HolidayRequest struct {
+	Date string `json:"date" example:"2025-08-17"`
+	Name string `json:"name" example:"Hari Kemerdekaan"`
+}
+
+type HolidayListRequest struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+}
+
+type HolidayResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Date string    `json:"date"`
+	Name string    `json:"name"`
+}
+
+type ImportHolidaysResponse struct {
+	Imported int               `json:"imported"`
+	Holidays []HolidayResponse `json:"holidays"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey is a request made with an Idempotency-Key header. It is
+// processing while the first request runs and completed once its response
+// is stored for replay.
+type IdempotencyKey struct {
+	ID                  uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID              uuid.UUID `json:"user_id"`
+	Key                 string    `json:"key" gorm:"column:idempotency_key"`
+	RequestHash         string    `json:"request_hash"`
+	Status              string    `json:"status" gorm:"default:'processing'"`
+	ResponseStatus      int       `json:"response_status"`
+	ResponseContentType string    `json:"response_content_type"`
+	ResponseBody        []byte    `json:"-"`
+	ExpiresAt           time.Time `json:"expires_at"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
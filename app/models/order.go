@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order is a user's cart: bookings held together while it is open and paid
+// for with a single payment once checked out.
+type Order struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `json:"user_id"`
+	Status    string    `json:"status" gorm:"default:'open'"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Order) TableName() string {
+	return "orders"
+}
+
+type CartItemRequest struct {
+	FieldID   uuid.UUID `json:"field_id" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// CartResponse is an order with its bookings; total is what its payment
+// asks for.
+type CartResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Status    string            `json:"status" example:"open"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Items     []BookingResponse `json:"items"`
+	Total     int               `json:"total" example:"300000"`
+	PaymentID *uuid.UUID        `json:"payment_id,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// CheckoutResponse is the checked out cart and the transaction paying for
+// all of its bookings, left out when nothing is to be paid.
+type CheckoutResponse struct {
+	Cart        CartResponse                `json:"cart"`
+	Transaction *PaymentTransactionResponse `json:"transaction,omitempty"`
+}
@@ -7,14 +7,23 @@ import (
 )
 
 type Payment struct {
-	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	BookingID     uuid.UUID  `json:"booking_id"`
-	Amount        int        `json:"amount"`
-	Status        string     `json:"status" gorm:"default:'pending'"`
-	PaymentMethod string     `json:"payment_method"`
-	PaidAt        *time.Time `json:"paid_at"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"` // ✅ Add this for better tracking
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BookingID      uuid.UUID  `json:"booking_id"`
+	SeriesID       *uuid.UUID `json:"series_id"` // set when paying for a whole series; BookingID is then its first booking
+	OrderID        *uuid.UUID `json:"order_id"`  // set when paying for a checked out cart; BookingID is then its first booking
+	Amount         int        `json:"amount"`    // final amount to pay, after any discount
+	OriginalAmount int        `json:"original_amount"`
+	DiscountAmount int        `json:"discount_amount"`
+	VoucherID      *uuid.UUID `json:"voucher_id"`
+	PaidAmount     int        `json:"paid_amount"`
+	RefundedAmount int        `json:"refunded_amount"`
+	Status         string     `json:"status" gorm:"default:'pending'"`
+	PaymentMethod  string     `json:"payment_method"`
+	PaidAt         *time.Time `json:"paid_at"`
+	SplitDeadline  *time.Time `json:"split_deadline"` // set once split into shares
+	DepositAmount  int        `json:"deposit_amount"` // paid up front to secure the booking; 0 when the full amount is due
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"` // ✅ Add this for better tracking
 
 }
 
@@ -22,24 +31,227 @@ func (Payment) TableName() string {
 	return "payments"
 }
 
+// PaymentAttempt is one gateway transaction for a payment. Every attempt has
+// its own gateway order ID; the payment aggregates the attempts' results.
+type PaymentAttempt struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PaymentID      uuid.UUID  `json:"payment_id"`
+	BookingID      uuid.UUID  `json:"booking_id"`
+	ShareID        *uuid.UUID `json:"share_id"` // set when paying a share of a split payment
+	OrderID        string     `json:"order_id"`
+	Amount         int        `json:"amount"`
+	RefundedAmount int        `json:"refunded_amount"`
+	Status         string     `json:"status" gorm:"default:'pending'"`
+	PaymentMethod  string     `json:"payment_method"`
+	TransactionID  string     `json:"transaction_id"`
+	SnapToken      string     `json:"snap_token"`
+	RedirectURL    string     `json:"redirect_url"`
+	PaidAt         *time.Time `json:"paid_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (PaymentAttempt) TableName() string {
+	return "payment_attempts"
+}
+
+type PaymentAttemptResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ShareID        *uuid.UUID `json:"share_id,omitempty"`
+	OrderID        string     `json:"order_id"`
+	Amount         int        `json:"amount"`
+	RefundedAmount int        `json:"refunded_amount"`
+	Status         string     `json:"status"`
+	PaymentMethod  string     `json:"payment_method"`
+	RedirectURL    string     `json:"redirect_url,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// PaymentNotification records a processed gateway webhook so retried or
+// replayed deliveries are only applied once.
+type PaymentNotification struct {
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	NotificationKey   string    `json:"notification_key"`
+	OrderID           string    `json:"order_id"`
+	TransactionID     string    `json:"transaction_id"`
+	TransactionStatus string    `json:"transaction_status"`
+	StatusCode        string    `json:"status_code"`
+	GrossAmount       string    `json:"gross_amount"`
+	Payload           string    `json:"payload" gorm:"type:jsonb"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (PaymentNotification) TableName() string {
+	return "payment_notifications"
+}
+
 type PaymentResponse struct {
+	ID             uuid.UUID                `json:"id"`
+	BookingID      uuid.UUID                `json:"booking_id"`
+	SeriesID       *uuid.UUID               `json:"series_id,omitempty"`
+	OrderID        *uuid.UUID               `json:"order_id,omitempty"`
+	OriginalAmount int                      `json:"original_amount"`
+	DiscountAmount int                      `json:"discount_amount"`
+	Amount         int                      `json:"amount"`
+	VoucherID      *uuid.UUID               `json:"voucher_id,omitempty"`
+	PaidAmount     int                      `json:"paid_amount"`
+	RefundedAmount int                      `json:"refunded_amount"`
+	Status         string                   `json:"status"`
+	PaymentMethod  string                   `json:"payment_method"`
+	PaidAt         *time.Time               `json:"paid_at,omitempty"`
+	SplitDeadline  *time.Time               `json:"split_deadline,omitempty"`
+	DepositAmount  int                      `json:"deposit_amount,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	Attempts       []PaymentAttemptResponse `json:"attempts,omitempty"`
+	Refunds        []RefundResponse         `json:"refunds,omitempty"`
+	Shares         []PaymentShareResponse   `json:"shares,omitempty"`
+}
+
+// PaymentShare is the part of a split payment one player pays. The share is
+// paid by the user registered with its email, through its own attempts.
+type PaymentShare struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PaymentID uuid.UUID  `json:"payment_id"`
+	BookingID uuid.UUID  `json:"booking_id"`
+	Email     string     `json:"email"`
+	Amount    int        `json:"amount"`
+	Status    string     `json:"status" gorm:"default:'pending'"`
+	PaidAt    *time.Time `json:"paid_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (PaymentShare) TableName() string {
+	return "payment_shares"
+}
+
+// SplitPaymentRequest splits a booking's payment evenly between its owner and
+// the invited emails. Without a deadline the shares can be paid for
+// SPLIT_PAYMENT_TTL, at most until the booking starts.
+type SplitPaymentRequest struct {
+	Emails   []string   `json:"emails" validate:"required" example:"budi@example.com,sari@example.com"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+type PaymentShareResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	BookingID uuid.UUID  `json:"booking_id"`
+	Email     string     `json:"email"`
+	Amount    int        `json:"amount" example:"30000"`
+	Status    string     `json:"status" example:"pending"`
+	PaidAt    *time.Time `json:"paid_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SplitPaymentResponse is a split payment with its shares; remaining is what
+// the owner pays to cover the shares still unpaid.
+type SplitPaymentResponse struct {
+	BookingID  uuid.UUID              `json:"booking_id"`
+	PaymentID  uuid.UUID              `json:"payment_id"`
+	Amount     int                    `json:"amount" example:"300000"`
+	PaidAmount int                    `json:"paid_amount" example:"60000"`
+	Remaining  int                    `json:"remaining" example:"240000"`
+	Deadline   time.Time              `json:"deadline"`
+	Shares     []PaymentShareResponse `json:"shares"`
+}
+
+// Refund is money returned from one payment attempt. Refunds started here
+// stay pending until the gateway accepts them; refunds made on the gateway's
+// side are recorded from its notifications.
+type Refund struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PaymentID       uuid.UUID  `json:"payment_id"`
+	AttemptID       uuid.UUID  `json:"attempt_id"`
+	BookingID       uuid.UUID  `json:"booking_id"`
+	Amount          int        `json:"amount"`
+	Reason          string     `json:"reason"`
+	RefundKey       string     `json:"refund_key"`
+	Status          string     `json:"status" gorm:"default:'pending'"`
+	Source          string     `json:"source"`
+	RequestedBy     *uuid.UUID `json:"requested_by"`
+	GatewayRefundID string     `json:"gateway_refund_id"`
+	FailureReason   string     `json:"failure_reason"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (Refund) TableName() string {
+	return "refunds"
+}
+
+// CashPaymentRequest records amount paid in cash at the counter; a zero
+// amount pays everything still due.
+type CashPaymentRequest struct {
+	Amount int `json:"amount" example:"210000"`
+}
+
+// RefundRequest refunds amount of a payment; a zero amount refunds everything
+// that has not been refunded yet.
+type RefundRequest struct {
+	Amount int    `json:"amount" example:"50000"`
+	Reason string `json:"reason" example:"Floodlights broken during the match"`
+}
+
+type RefundResponse struct {
 	ID            uuid.UUID  `json:"id"`
-	BookingID     uuid.UUID  `json:"booking_id"`
+	AttemptID     uuid.UUID  `json:"attempt_id"`
 	Amount        int        `json:"amount"`
+	Reason        string     `json:"reason"`
 	Status        string     `json:"status"`
-	PaymentMethod string     `json:"payment_method"`
-	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	Source        string     `json:"source"`
+	RequestedBy   *uuid.UUID `json:"requested_by,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
 }
 
 type PaymentTransactionResponse struct {
 	PaymentID     uuid.UUID `json:"payment_id"`
+	AttemptID     uuid.UUID `json:"attempt_id"`
+	OrderID       string    `json:"order_id"`
 	Token         string    `json:"token"`
 	RedirectURL   string    `json:"redirect_url"`
 	TransactionID string    `json:"transaction_id"`
 	Amount        int       `json:"amount"`
 }
 
+// Credit is money a user keeps with the venue, e.g. the difference when a
+// paid booking moves to a cheaper slot.
+type Credit struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"user_id"`
+	BookingID *uuid.UUID `json:"booking_id"`
+	PaymentID *uuid.UUID `json:"payment_id"`
+	Amount    int        `json:"amount"`
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Credit) TableName() string {
+	return "credits"
+}
+
+type CreditResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	BookingID *uuid.UUID `json:"booking_id,omitempty"`
+	Amount    int        `json:"amount" example:"50000"`
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreditBalanceResponse is a user's credit balance with the credits making
+// it up.
+type CreditBalanceResponse struct {
+	Balance int              `json:"balance" example:"50000"`
+	Credits []CreditResponse `json:"credits"`
+}
+
+// SimulatePaymentRequest drives a transaction of the offline fake gateway.
+type SimulatePaymentRequest struct {
+	TransactionStatus string `json:"transaction_status" validate:"required"`
+	PaymentType       string `json:"payment_type"`
+}
+
 type CreatePaymentRequest struct {
 	BookingID     uuid.UUID `json:"booking_id" validate:"required"`
 	PaymentMethod string    `json:"payment_method" validate:"required"`
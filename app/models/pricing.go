@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricingRule overrides the base price of a field on the days matching
+// DayType between StartTime and EndTime ("HH:MM" in the venue timezone,
+// "24:00" ending at midnight). Where rules overlap the highest Priority wins.
+type PricingRule struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FieldID      uuid.UUID `json:"field_id"`
+	Name         string    `json:"name"`
+	DayType      string    `json:"day_type"`
+	StartTime    string    `json:"start_time"`
+	EndTime      string    `json:"end_time"`
+	PricePerHour int       `json:"price_per_hour"`
+	Priority     int       `json:"priority"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
+
+type PricingRuleRequest struct {
+	Name         string `json:"name" example:"Weekend evening"`
+	DayType      string `json:"day_type" example:"weekend"`
+	StartTime    string `json:"start_time" example:"17:00"`
+	EndTime      string `json:"end_time" example:"24:00"`
+	PricePerHour int    `json:"price_per_hour" example:"150000"`
+	Priority     int    `json:"priority"`
+}
+
+type PricingRuleResponse struct {
+	ID           uuid.UUID `json:"id"`
+	FieldID      uuid.UUID `json:"field_id"`
+	Name         string    `json:"name"`
+	DayType      string    `json:"day_type"`
+	StartTime    string    `json:"start_time"`
+	EndTime      string    `json:"end_time"`
+	PricePerHour int       `json:"price_per_hour"`
+	Priority     int       `json:"priority"`
+}
+
+type PriceQuoteRequest struct {
+	Start string `query:"start"`
+	End   string `query:"end"`
+}
+
+// PriceQuoteLine is a stretch of the booking charged at one hourly price.
+type PriceQuoteLine struct {
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	Minutes      int        `json:"minutes"`
+	PricePerHour int        `json:"price_per_hour"`
+	RuleID       *uuid.UUID `json:"rule_id,omitempty"`
+	RuleName     string     `json:"rule_name,omitempty"`
+	Amount       int        `json:"amount"`
+}
+
+type PriceQuoteResponse struct {
+	FieldID   uuid.UUID        `json:"field_id"`
+	StartTime time.Time        `json:"start_time"`
+	EndTime   time.Time        `json:"end_time"`
+	Minutes   int              `json:"minutes"`
+	Amount    int              `json:"amount"`
+	Lines     []PriceQuoteLine `json:"lines"`
+}
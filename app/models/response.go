@@ -26,3 +26,12 @@ type Pagination struct {
 	Total     int `json:"total,omitempty"`
 	TotalPage int `json:"total_page,omitempty"`
 }
+
+// PageRequest holds the page, page_size and sort query parameters shared by
+// list endpoints. sort is a comma-separated list of columns, each optionally
+// prefixed with "-" for descending order.
+type PageRequest struct {
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+	Sort     string `query:"sort"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actor is who or what triggered a status change. ID is set when a user or
+// an admin did.
+type Actor struct {
+	Source string
+	ID     *uuid.UUID
+}
+
+// StatusChange is a requested status transition of a booking or payment.
+type StatusChange struct {
+	To     string
+	Actor  Actor
+	Reason string
+}
+
+// StatusHistory records one status transition of a booking or payment.
+type StatusHistory struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Source     string     `json:"source"`
+	ActorID    *uuid.UUID `json:"actor_id"`
+	Reason     string     `json:"reason"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (StatusHistory) TableName() string {
+	return "status_history"
+}
+
+type StatusHistoryResponse struct {
+	EntityType string     `json:"entity_type" example:"booking"`
+	FromStatus string     `json:"from_status" example:"pending"`
+	ToStatus   string     `json:"to_status" example:"paid"`
+	Source     string     `json:"source" example:"gateway"`
+	ActorID    *uuid.UUID `json:"actor_id,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -29,11 +29,16 @@ type UserResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// RegisterRequest is the public sign-up payload. The role is not accepted
+// from the client: public registration always creates a regular user.
 type RegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
-	Role     string `json:"role" validate:"required,oneof=user admin"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
 }
 
 type LoginRequest struct {
@@ -42,8 +47,11 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token            string       `json:"token"`
+	RefreshToken     string       `json:"refresh_token"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             UserResponse `json:"user"`
 }
 
 // GORM Hook
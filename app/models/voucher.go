@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Voucher is a promo code applied when a booking is created. Nil limits and
+// validity bounds mean unlimited; an empty FieldIDs makes it valid for every
+// field.
+type Voucher struct {
+	ID            uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code          string      `json:"code"`
+	Description   string      `json:"description"`
+	DiscountType  string      `json:"discount_type"`
+	DiscountValue int         `json:"discount_value"`
+	MaxDiscount   *int        `json:"max_discount"`
+	MinAmount     int         `json:"min_amount"`
+	UsageLimit    *int        `json:"usage_limit"`
+	PerUserLimit  *int        `json:"per_user_limit"`
+	UsedCount     int         `json:"used_count"`
+	ValidFrom     *time.Time  `json:"valid_from"`
+	ValidUntil    *time.Time  `json:"valid_until"`
+	Active        bool        `json:"active"`
+	FieldIDs      []uuid.UUID `json:"field_ids" gorm:"-"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (Voucher) TableName() string {
+	return "vouchers"
+}
+
+type VoucherField struct {
+	VoucherID uuid.UUID `json:"voucher_id" gorm:"type:uuid;primaryKey"`
+	FieldID   uuid.UUID `json:"field_id" gorm:"type:uuid;primaryKey"`
+}
+
+func (VoucherField) TableName() string {
+	return "voucher_fields"
+}
+
+// VoucherRedemption records that a voucher was used for a booking.
+type VoucherRedemption struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	VoucherID      uuid.UUID `json:"voucher_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	BookingID      uuid.UUID `json:"booking_id"`
+	DiscountAmount int       `json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (VoucherRedemption) TableName() string {
+	return "voucher_redemptions"
+}
+
+type VoucherRequest struct {
+	Code          string      `json:"code" example:"WEEKEND20"`
+	Description   string      `json:"description" example:"20% off weekend bookings"`
+	DiscountType  string      `json:"discount_type" example:"percentage"`
+	DiscountValue int         `json:"discount_value" example:"20"`
+	MaxDiscount   *int        `json:"max_discount" example:"50000"`
+	MinAmount     int         `json:"min_amount"`
+	UsageLimit    *int        `json:"usage_limit" example:"100"`
+	PerUserLimit  *int        `json:"per_user_limit" example:"1"`
+	ValidFrom     *time.Time  `json:"valid_from"`
+	ValidUntil    *time.Time  `json:"valid_until"`
+	Active        *bool       `json:"active"`
+	FieldIDs      []uuid.UUID `json:"field_ids"`
+}
+
+type VoucherResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	Code          string      `json:"code"`
+	Description   string      `json:"description"`
+	DiscountType  string      `json:"discount_type"`
+	DiscountValue int         `json:"discount_value"`
+	MaxDiscount   *int        `json:"max_discount"`
+	MinAmount     int         `json:"min_amount"`
+	UsageLimit    *int        `json:"usage_limit"`
+	PerUserLimit  *int        `json:"per_user_limit"`
+	UsedCount     int         `json:"used_count"`
+	ValidFrom     *time.Time  `json:"valid_from"`
+	ValidUntil    *time.Time  `json:"valid_until"`
+	Active        bool        `json:"active"`
+	FieldIDs      []uuid.UUID `json:"field_ids"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
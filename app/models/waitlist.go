@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistEntry is a user waiting for a taken slot of a field. When the slot
+// frees up the entry is offered a held booking until OfferExpiresAt.
+type WaitlistEntry struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID         uuid.UUID  `json:"user_id"`
+	FieldID        uuid.UUID  `json:"field_id"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        time.Time  `json:"end_time"`
+	Status         string     `json:"status"`
+	BookingID      *uuid.UUID `json:"booking_id"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+type WaitlistRequest struct {
+	FieldID   uuid.UUID `json:"field_id" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+type WaitlistEntryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	FieldID        uuid.UUID  `json:"field_id"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        time.Time  `json:"end_time"`
+	Status         string     `json:"status" example:"waiting"`
+	BookingID      *uuid.UUID `json:"booking_id,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Notification is an in-app message to a user.
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	BookingID *uuid.UUID `json:"booking_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+type NotificationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type" example:"waitlist_offer"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	BookingID *uuid.UUID `json:"booking_id,omitempty"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationListRequest pages through a user's notifications; unread
+// limits it to the ones not read yet.
+type NotificationListRequest struct {
+	PageRequest
+	Unread bool `query:"unread"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"take-home-test/app/constants"
 	"take-home-test/app/models"
+	"take-home-test/app/statemachine"
 	"take-home-test/pkg/customerror" // Ganti dari customerrors menjadi customerror
 	"time"
 
@@ -17,14 +18,33 @@ type bookingRepository struct {
 type BookingInterface interface {
 	CreateBooking(ctx context.Context, booking models.Booking) (models.Booking, error)
 	GetBookingByID(ctx context.Context, id string) (models.Booking, error)
-	GetBookingsByUserID(ctx context.Context, userID string) ([]models.Booking, error)
-	CheckTimeOverlap(ctx context.Context, fieldID string, startTime, endTime time.Time) (bool, error)
-	UpdateBookingStatus(ctx context.Context, id string, status string) error
+	ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) ([]models.Booking, *models.Pagination, error)
+	CheckTimeOverlap(ctx context.Context, fieldID string, startTime, endTime time.Time, excludeBookingID string) (bool, error)
+	RescheduleBooking(ctx context.Context, id string, startTime, endTime time.Time, price int) error
+	TransitionBooking(ctx context.Context, id string, change models.StatusChange) error
+	ConfirmHold(ctx context.Context, id string, change models.StatusChange, holdExpiresAt *time.Time, price int) error
+	GetExpiredPendingBookings(ctx context.Context, now time.Time, limit int) ([]models.Booking, error)
+	GetFinishedBookings(ctx context.Context, now time.Time, limit int) ([]models.Booking, error)
+	GetFieldBookingsInRange(ctx context.Context, fieldID string, from, to time.Time) ([]models.Booking, error)
+	CreateSeries(ctx context.Context, series models.BookingSeries) (models.BookingSeries, error)
+	GetSeriesBookings(ctx context.Context, seriesID string) ([]models.Booking, error)
+	GetOrderBookings(ctx context.Context, orderID string) ([]models.Booking, error)
+	ExtendOrderHold(ctx context.Context, orderID string, holdExpiresAt time.Time) error
+	ExtendHold(ctx context.Context, id string, holdExpiresAt time.Time) (bool, error)
 }
 
+// CreateBooking inserts the booking. The bookings_no_overlap constraint makes
+// Postgres reject a slot that overlaps another non-canceled booking of the
+// field, which is reported as a Conflict error.
 func (r *bookingRepository) CreateBooking(ctx context.Context, booking models.Booking) (models.Booking, error) {
 	err := r.Options.Postgres.WithContext(ctx).Create(&booking).Error
-	return booking, err
+	if err != nil {
+		if pgErrorCode(err) == pgExclusionViolation {
+			return booking, customerror.NewConflictError(constants.ErrTimeSlotOverlap)
+		}
+		return booking, customerror.NewInternalServiceError(err.Error())
+	}
+	return booking, nil
 }
 
 func (r *bookingRepository) GetBookingByID(ctx context.Context, id string) (models.Booking, error) {
@@ -40,22 +60,63 @@ func (r *bookingRepository) GetBookingByID(ctx context.Context, id string) (mode
 	return booking, nil
 }
 
-func (r *bookingRepository) GetBookingsByUserID(ctx context.Context, userID string) ([]models.Booking, error) {
+// bookingSortColumns are the sort keys accepted by ListBookings.
+var bookingSortColumns = map[string]string{
+	"start_time": "start_time",
+	"end_time":   "end_time",
+	"status":     "status",
+	"created_at": "created_at",
+}
+
+// ListBookings pages through the bookings matching filter; the time range
+// matches bookings overlapping it.
+func (r *bookingRepository) ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) ([]models.Booking, *models.Pagination, error) {
+	query := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.FieldID != "" {
+		query = query.Where("field_id = ?", filter.FieldID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.From != nil {
+		query = query.Where("end_time > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+	if filter.Search != "" {
+		pattern := containsPattern(filter.Search)
+		query = query.Where(
+			"customer_name ILIKE ? OR customer_phone ILIKE ? OR "+
+				"user_id IN (SELECT id FROM users WHERE name ILIKE ? OR email ILIKE ?) OR "+
+				"field_id IN (SELECT id FROM fields WHERE name ILIKE ?)",
+			pattern, pattern, pattern, pattern, pattern,
+		)
+	}
+
 	var bookings []models.Booking
-	err := r.Options.Postgres.WithContext(ctx).Where("user_id = ?", userID).Find(&bookings).Error
-	return bookings, err
+	pagination, err := paginate(query, page, bookingSortColumns, "-start_time", &bookings)
+	return bookings, pagination, err
 }
 
-func (r *bookingRepository) CheckTimeOverlap(ctx context.Context, fieldID string, startTime, endTime time.Time) (bool, error) {
+// CheckTimeOverlap reports whether a non-canceled booking of the field other
+// than excludeBookingID overlaps the range.
+func (r *bookingRepository) CheckTimeOverlap(ctx context.Context, fieldID string, startTime, endTime time.Time, excludeBookingID string) (bool, error) {
 	var count int64
 
-	err := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{}).
+	query := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{}).
 		Where("field_id = ? AND status != ?", fieldID, constants.BOOKING_STATUS_CANCELED).
 		Where("(start_time < ? AND end_time > ?) OR (start_time < ? AND end_time > ?) OR (start_time >= ? AND end_time <= ?)",
 			endTime, startTime,
 			startTime, endTime,
-			startTime, endTime).
-		Count(&count).Error
+			startTime, endTime)
+	if excludeBookingID != "" {
+		query = query.Where("id <> ?", excludeBookingID)
+	}
+	err := query.Count(&count).Error
 
 	if err != nil {
 		return false, customerror.NewInternalServiceError(err.Error()) // Ganti menjadi customerror.
@@ -64,18 +125,203 @@ func (r *bookingRepository) CheckTimeOverlap(ctx context.Context, fieldID string
 	return count > 0, nil
 }
 
-func (r *bookingRepository) UpdateBookingStatus(ctx context.Context, id string, status string) error {
+// TransitionBooking moves the booking to change.To when the booking state
+// machine allows it from its current status, and records the change. A
+// refused change is a BadRequest, so of several concurrent cancellations
+// only one succeeds and a refund is never issued twice. The payment hold is
+// cleared as the booking leaves pending.
+func (r *bookingRepository) TransitionBooking(ctx context.Context, id string, change models.StatusChange) error {
+	from, applied, err := transition(r.Options.Postgres.WithContext(ctx), &models.Booking{}, statemachine.Booking, id, change, map[string]interface{}{
+		"hold_expires_at": nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	if from == "" {
+		return customerror.NewNotFoundErrorf(constants.ErrBookingNotFound, id)
+	}
+
+	if !applied {
+		return statemachine.Booking.Check(from, change.To)
+	}
+
+	return nil
+}
+
+// reschedulableStatuses are the statuses of a booking that can still be
+// moved to another slot.
+var reschedulableStatuses = []string{
+	constants.BOOKING_STATUS_PENDING,
+	constants.BOOKING_STATUS_PARTIALLY_PAID,
+	constants.BOOKING_STATUS_PAID,
+	constants.BOOKING_STATUS_CONFIRMED,
+}
+
+// RescheduleBooking moves the booking to [startTime, endTime) at price, as
+// long as it is still pending, partially paid, paid or confirmed. Like
+// CreateBooking, a slot overlapping another booking of the field is rejected
+// by the bookings_no_overlap constraint and reported as a Conflict error.
+func (r *bookingRepository) RescheduleBooking(ctx context.Context, id string, startTime, endTime time.Time, price int) error {
 	result := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{}).
-		Where("id = ?", id).
-		Update("status", status)
+		Where("id = ? AND status IN ?", id, reschedulableStatuses).
+		Updates(map[string]interface{}{
+			"start_time": startTime,
+			"end_time":   endTime,
+			"price":      price,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
 
 	if result.Error != nil {
-		return customerror.NewInternalServiceError(result.Error.Error()) // Ganti menjadi customerror.
+		if pgErrorCode(result.Error) == pgExclusionViolation {
+			return customerror.NewConflictError(constants.ErrTimeSlotOverlap)
+		}
+		return customerror.NewInternalServiceError(result.Error.Error())
 	}
-
 	if result.RowsAffected == 0 {
-		return customerror.NewNotFoundErrorf(constants.ErrBookingNotFound, id) // Ganti menjadi customerror.
+		return customerror.NewBadRequestError(constants.ErrBookingNotReschedulable)
+	}
+	return nil
+}
+
+// ConfirmHold turns a held booking into a regular one: it moves to
+// change.To, priced at price and held until holdExpiresAt while it waits for
+// its payment.
+func (r *bookingRepository) ConfirmHold(ctx context.Context, id string, change models.StatusChange, holdExpiresAt *time.Time, price int) error {
+	machine := statemachine.Booking.From(constants.BOOKING_STATUS_HELD)
+	from, applied, err := transition(r.Options.Postgres.WithContext(ctx), &models.Booking{}, machine, id, change, map[string]interface{}{
+		"hold_expires_at": holdExpiresAt,
+		"price":           price,
+	})
+	if err != nil {
+		return err
+	}
+
+	if from == "" {
+		return customerror.NewNotFoundErrorf(constants.ErrBookingNotFound, id)
+	}
+
+	if !applied {
+		return machine.Check(from, change.To)
 	}
 
 	return nil
 }
+
+// GetExpiredPendingBookings lists the pending bookings and waitlist offers
+// whose hold has passed, oldest first.
+func (r *bookingRepository) GetExpiredPendingBookings(ctx context.Context, now time.Time, limit int) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("status IN ? AND hold_expires_at <= ?", []string{constants.BOOKING_STATUS_PENDING, constants.BOOKING_STATUS_HELD}, now).
+		Order("hold_expires_at ASC").
+		Limit(limit).
+		Find(&bookings).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return bookings, nil
+}
+
+// GetFinishedBookings lists partially paid, paid and confirmed bookings that
+// ended by now, oldest first.
+func (r *bookingRepository) GetFinishedBookings(ctx context.Context, now time.Time, limit int) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("status IN ? AND end_time <= ?", []string{constants.BOOKING_STATUS_PARTIALLY_PAID, constants.BOOKING_STATUS_PAID, constants.BOOKING_STATUS_CONFIRMED}, now).
+		Order("end_time ASC").
+		Limit(limit).
+		Find(&bookings).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return bookings, nil
+}
+
+// GetFieldBookingsInRange lists the non-canceled bookings of a field that
+// overlap [from, to), ordered by start time.
+func (r *bookingRepository) GetFieldBookingsInRange(ctx context.Context, fieldID string, from, to time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ? AND status <> ?", fieldID, constants.BOOKING_STATUS_CANCELED).
+		Where("start_time < ? AND end_time > ?", to, from).
+		Order("start_time ASC").
+		Find(&bookings).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return bookings, nil
+}
+
+func (r *bookingRepository) CreateSeries(ctx context.Context, series models.BookingSeries) (models.BookingSeries, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&series).Error
+	if err != nil {
+		return series, customerror.NewInternalServiceError(err.Error())
+	}
+	return series, nil
+}
+
+// GetSeriesBookings lists every booking of a series, canceled ones included,
+// ordered by start time.
+func (r *bookingRepository) GetSeriesBookings(ctx context.Context, seriesID string) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("series_id = ?", seriesID).
+		Order("start_time ASC").
+		Find(&bookings).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return bookings, nil
+}
+
+// GetOrderBookings lists every booking of an order, canceled ones included,
+// ordered by start time.
+func (r *bookingRepository) GetOrderBookings(ctx context.Context, orderID string) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("start_time ASC").
+		Find(&bookings).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return bookings, nil
+}
+
+// ExtendOrderHold holds the pending bookings of an order until
+// holdExpiresAt.
+func (r *bookingRepository) ExtendOrderHold(ctx context.Context, orderID string, holdExpiresAt time.Time) error {
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{}).
+		Where("order_id = ? AND status = ?", orderID, constants.BOOKING_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"hold_expires_at": holdExpiresAt,
+			"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+// ExtendHold holds a pending booking until holdExpiresAt, and reports
+// whether it was still pending.
+func (r *bookingRepository) ExtendHold(ctx context.Context, id string, holdExpiresAt time.Time) (bool, error) {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Booking{}).
+		Where("id = ? AND status = ?", id, constants.BOOKING_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"hold_expires_at": holdExpiresAt,
+			"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+)
+
+type creditRepository struct {
+	Options Options
+}
+
+type CreditInterface interface {
+	CreateCredit(ctx context.Context, credit models.Credit) (models.Credit, error)
+	GetUserCredits(ctx context.Context, userID string) ([]models.Credit, error)
+}
+
+func (r *creditRepository) CreateCredit(ctx context.Context, credit models.Credit) (models.Credit, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&credit).Error
+	if err != nil {
+		return credit, customerror.NewInternalServiceError(err.Error())
+	}
+	return credit, nil
+}
+
+// GetUserCredits lists the credits of a user, latest first.
+func (r *creditRepository) GetUserCredits(ctx context.Context, userID string) ([]models.Credit, error) {
+	var credits []models.Credit
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&credits).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return credits, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fieldBlackoutRepository struct {
+	Options Options
+}
+
+type FieldBlackoutInterface interface {
+	GetBlackoutsByFieldID(ctx context.Context, fieldID string) ([]models.FieldBlackout, error)
+	GetBlackoutByID(ctx context.Context, fieldID, id string) (models.FieldBlackout, error)
+	GetBlackoutsInRange(ctx context.Context, fieldID string, from, to time.Time) ([]models.FieldBlackout, error)
+	CreateBlackout(ctx context.Context, blackout models.FieldBlackout) (models.FieldBlackout, error)
+	DeleteBlackout(ctx context.Context, fieldID, id string) error
+}
+
+func (r *fieldBlackoutRepository) GetBlackoutsByFieldID(ctx context.Context, fieldID string) ([]models.FieldBlackout, error) {
+	var blackouts []models.FieldBlackout
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("start_time ASC").
+		Find(&blackouts).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return blackouts, nil
+}
+
+func (r *fieldBlackoutRepository) GetBlackoutByID(ctx context.Context, fieldID, id string) (models.FieldBlackout, error) {
+	var blackout models.FieldBlackout
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("id = ? AND field_id = ?", id, fieldID).
+		First(&blackout).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return blackout, customerror.NewNotFoundErrorf(constants.ErrFieldBlackoutNotFound, id)
+		}
+		return blackout, customerror.NewInternalServiceError(err.Error())
+	}
+	return blackout, nil
+}
+
+// GetBlackoutsInRange lists the blackouts of a field that overlap
+// [from, to), ordered by start time.
+func (r *fieldBlackoutRepository) GetBlackoutsInRange(ctx context.Context, fieldID string, from, to time.Time) ([]models.FieldBlackout, error) {
+	var blackouts []models.FieldBlackout
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Where("start_time < ? AND end_time > ?", to, from).
+		Order("start_time ASC").
+		Find(&blackouts).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return blackouts, nil
+}
+
+func (r *fieldBlackoutRepository) CreateBlackout(ctx context.Context, blackout models.FieldBlackout) (models.FieldBlackout, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&blackout).Error
+	if err != nil {
+		return blackout, customerror.NewInternalServiceError(err.Error())
+	}
+	return blackout, nil
+}
+
+func (r *fieldBlackoutRepository) DeleteBlackout(ctx context.Context, fieldID, id string) error {
+	result := r.Options.Postgres.WithContext(ctx).
+		Where("id = ? AND field_id = ?", id, fieldID).
+		Delete(&models.FieldBlackout{})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrFieldBlackoutNotFound, id)
+	}
+
+	return nil
+}
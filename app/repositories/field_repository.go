@@ -16,7 +16,9 @@ type fieldRepository struct {
 type FieldInterface interface {
 	CreateField(ctx context.Context, field models.Field) (models.Field, error)
 	GetFields(ctx context.Context) ([]models.Field, error)
+	ListFields(ctx context.Context, filter models.FieldFilter, page models.PageRequest) ([]models.Field, *models.Pagination, error)
 	GetFieldByID(ctx context.Context, id string) (models.Field, error)
+	GetFieldsByIDs(ctx context.Context, ids []string) ([]models.Field, error)
 	UpdateField(ctx context.Context, field models.Field) (models.Field, error)
 	DeleteField(ctx context.Context, id string) error
 }
@@ -32,6 +34,34 @@ func (r *fieldRepository) GetFields(ctx context.Context) ([]models.Field, error)
 	return fields, err
 }
 
+// fieldSortColumns are the sort keys accepted by ListFields.
+var fieldSortColumns = map[string]string{
+	"name":           "name",
+	"location":       "location",
+	"price_per_hour": "price_per_hour",
+	"created_at":     "created_at",
+}
+
+func (r *fieldRepository) ListFields(ctx context.Context, filter models.FieldFilter, page models.PageRequest) ([]models.Field, *models.Pagination, error) {
+	query := r.Options.Postgres.WithContext(ctx).Model(&models.Field{})
+	if filter.Name != "" {
+		query = query.Where("name ILIKE ?", containsPattern(filter.Name))
+	}
+	if filter.Location != "" {
+		query = query.Where("location ILIKE ?", containsPattern(filter.Location))
+	}
+	if filter.MinPrice != nil {
+		query = query.Where("price_per_hour >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where("price_per_hour <= ?", *filter.MaxPrice)
+	}
+
+	var fields []models.Field
+	pagination, err := paginate(query, page, fieldSortColumns, "name", &fields)
+	return fields, pagination, err
+}
+
 func (r *fieldRepository) GetFieldByID(ctx context.Context, id string) (models.Field, error) {
 	var field models.Field
 	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).First(&field).Error
@@ -45,6 +75,18 @@ func (r *fieldRepository) GetFieldByID(ctx context.Context, id string) (models.F
 	return field, nil
 }
 
+// GetFieldsByIDs loads the fields with the given IDs; unknown IDs are
+// skipped.
+func (r *fieldRepository) GetFieldsByIDs(ctx context.Context, ids []string) ([]models.Field, error) {
+	var fields []models.Field
+	err := r.Options.Postgres.WithContext(ctx).Where("id IN ?", ids).Find(&fields).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return fields, nil
+}
+
 func (r *fieldRepository) UpdateField(ctx context.Context, field models.Field) (models.Field, error) {
 	err := r.Options.Postgres.WithContext(ctx).Save(&field).Error
 	return field, err
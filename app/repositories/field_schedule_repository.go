@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+)
+
+type fieldScheduleRepository struct {
+	Options Options
+}
+
+type FieldScheduleInterface interface {
+	GetSchedulesByFieldID(ctx context.Context, fieldID string) ([]models.FieldSchedule, error)
+	CreateSchedule(ctx context.Context, schedule models.FieldSchedule) (models.FieldSchedule, error)
+	ReplaceSchedules(ctx context.Context, fieldID string, schedules []models.FieldSchedule) ([]models.FieldSchedule, error)
+	DeleteSchedule(ctx context.Context, fieldID, id string) error
+}
+
+func (r *fieldScheduleRepository) GetSchedulesByFieldID(ctx context.Context, fieldID string) ([]models.FieldSchedule, error) {
+	var schedules []models.FieldSchedule
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("open_time ASC").
+		Find(&schedules).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return schedules, nil
+}
+
+func (r *fieldScheduleRepository) CreateSchedule(ctx context.Context, schedule models.FieldSchedule) (models.FieldSchedule, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&schedule).Error
+	if err != nil {
+		return schedule, customerror.NewInternalServiceError(err.Error())
+	}
+	return schedule, nil
+}
+
+// ReplaceSchedules swaps the whole weekly schedule of a field in one
+// transaction. An empty list removes the opening hours altogether.
+func (r *fieldScheduleRepository) ReplaceSchedules(ctx context.Context, fieldID string, schedules []models.FieldSchedule) ([]models.FieldSchedule, error) {
+	err := r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", fieldID).Delete(&models.FieldSchedule{}).Error; err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+		return tx.Create(&schedules).Error
+	})
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return schedules, nil
+}
+
+func (r *fieldScheduleRepository) DeleteSchedule(ctx context.Context, fieldID, id string) error {
+	result := r.Options.Postgres.WithContext(ctx).
+		Where("id = ? AND field_id = ?", id, fieldID).
+		Delete(&models.FieldSchedule{})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrFieldScheduleNotFound, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type holidayRepository struct {
+	Options Options
+}
+
+type HolidayInterface interface {
+	GetHolidays(ctx context.Context, from, to *time.Time) ([]models.Holiday, error)
+	UpsertHolidays(ctx context.Context, holidays []models.Holiday) ([]models.Holiday, error)
+	DeleteHoliday(ctx context.Context, id string) error
+}
+
+// GetHolidays lists holidays ordered by date, optionally limited to dates on
+// or after from and on or before to.
+func (r *holidayRepository) GetHolidays(ctx context.Context, from, to *time.Time) ([]models.Holiday, error) {
+	query := r.Options.Postgres.WithContext(ctx)
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date <= ?", *to)
+	}
+
+	var holidays []models.Holiday
+	if err := query.Order("date ASC").Find(&holidays).Error; err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return holidays, nil
+}
+
+// UpsertHolidays stores the holidays, renaming the ones whose date already
+// exists, so importing the same calendar twice is harmless.
+func (r *holidayRepository) UpsertHolidays(ctx context.Context, holidays []models.Holiday) ([]models.Holiday, error) {
+	if len(holidays) == 0 {
+		return holidays, nil
+	}
+
+	err := r.Options.Postgres.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "date"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"name":       clause.Column{Table: "excluded", Name: "name"},
+				"updated_at": clause.Expr{SQL: "CURRENT_TIMESTAMP"},
+			}),
+		}).
+		Create(&holidays).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return holidays, nil
+}
+
+func (r *holidayRepository) DeleteHoliday(ctx context.Context, id string) error {
+	result := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).Delete(&models.Holiday{})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrHolidayNotFound, id)
+	}
+
+	return nil
+}
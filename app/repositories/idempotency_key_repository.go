@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyKeyRepository struct {
+	Options Options
+}
+
+type IdempotencyKeyInterface interface {
+	ReserveKey(ctx context.Context, key models.IdempotencyKey, staleBefore time.Time) (bool, error)
+	GetKey(ctx context.Context, userID, key string) (models.IdempotencyKey, error)
+	CompleteKey(ctx context.Context, userID, key string, status int, contentType string, body []byte) error
+	ReleaseKey(ctx context.Context, userID, key string) error
+	DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ReserveKey claims the key for a new request. An expired key, or one whose
+// request has been processing since before staleBefore (e.g. the server
+// stopped mid-request), is taken over. It returns false when the key is
+// held by another request.
+func (r *idempotencyKeyRepository) ReserveKey(ctx context.Context, key models.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	key.Status = constants.IDEMPOTENCY_STATUS_PROCESSING
+
+	result := r.Options.Postgres.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "idempotency_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"request_hash":          clause.Column{Table: "excluded", Name: "request_hash"},
+				"expires_at":            clause.Column{Table: "excluded", Name: "expires_at"},
+				"status":                constants.IDEMPOTENCY_STATUS_PROCESSING,
+				"response_status":       0,
+				"response_content_type": "",
+				"response_body":         nil,
+				"created_at":            clause.Expr{SQL: "CURRENT_TIMESTAMP"},
+				"updated_at":            clause.Expr{SQL: "CURRENT_TIMESTAMP"},
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Or(
+					clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: time.Now()},
+					clause.And(
+						clause.Eq{Column: clause.Column{Table: "idempotency_keys", Name: "status"}, Value: constants.IDEMPOTENCY_STATUS_PROCESSING},
+						clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "updated_at"}, Value: staleBefore},
+					),
+				),
+			}},
+		}).
+		Create(&key)
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *idempotencyKeyRepository) GetKey(ctx context.Context, userID, key string) (models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		First(&record).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return record, customerror.NewNotFoundErrorf(constants.ErrIdempotencyKeyNotFound, key)
+		}
+		return record, customerror.NewInternalServiceError(err.Error())
+	}
+	return record, nil
+}
+
+// CompleteKey stores the response of the request holding the key.
+func (r *idempotencyKeyRepository) CompleteKey(ctx context.Context, userID, key string, status int, contentType string, body []byte) error {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.IdempotencyKey{}).
+		Where("user_id = ? AND idempotency_key = ? AND status = ?", userID, key, constants.IDEMPOTENCY_STATUS_PROCESSING).
+		Updates(map[string]interface{}{
+			"status":                constants.IDEMPOTENCY_STATUS_COMPLETED,
+			"response_status":       status,
+			"response_content_type": contentType,
+			"response_body":         body,
+			"updated_at":            gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrIdempotencyKeyNotFound, key)
+	}
+	return nil
+}
+
+// ReleaseKey forgets a key whose request did not complete, so a retry runs
+// the request again.
+func (r *idempotencyKeyRepository) ReleaseKey(ctx context.Context, userID, key string) error {
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("user_id = ? AND idempotency_key = ? AND status = ?", userID, key, constants.IDEMPOTENCY_STATUS_PROCESSING).
+		Delete(&models.IdempotencyKey{}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := r.Options.Postgres.WithContext(ctx).
+		Where("expires_at < ?", now).
+		Delete(&models.IdempotencyKey{})
+
+	if result.Error != nil {
+		return 0, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
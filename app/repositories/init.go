@@ -1,16 +1,33 @@
 package repositories
 
 import (
+	"context"
 	"take-home-test/pkg/config"
 
 	"gorm.io/gorm"
 )
 
 type Main struct {
-	User    UserInterface
-	Field   FieldInterface
-	Booking BookingInterface
-	Payment PaymentInterface
+	User          UserInterface
+	Field         FieldInterface
+	Booking       BookingInterface
+	Payment       PaymentInterface
+	Session       SessionInterface
+	FieldSchedule FieldScheduleInterface
+	FieldBlackout FieldBlackoutInterface
+	Holiday       HolidayInterface
+	PricingRule   PricingRuleInterface
+	Voucher       VoucherInterface
+	Refund        RefundInterface
+	Idempotency   IdempotencyKeyInterface
+	StatusHistory StatusHistoryInterface
+	Waitlist      WaitlistInterface
+	Notification  NotificationInterface
+	Credit        CreditInterface
+	Order         OrderInterface
+	Share         ShareInterface
+
+	options Options
 }
 
 type repository struct {
@@ -26,11 +43,34 @@ func Init(opts Options) *Main {
 	repo := &repository{opts}
 
 	m := &Main{
-		User:    (*userRepository)(repo),
-		Field:   (*fieldRepository)(repo),
-		Booking: (*bookingRepository)(repo),
-		Payment: (*paymentRepository)(repo),
+		User:          (*userRepository)(repo),
+		Field:         (*fieldRepository)(repo),
+		Booking:       (*bookingRepository)(repo),
+		Payment:       (*paymentRepository)(repo),
+		Session:       (*sessionRepository)(repo),
+		FieldSchedule: (*fieldScheduleRepository)(repo),
+		FieldBlackout: (*fieldBlackoutRepository)(repo),
+		Holiday:       (*holidayRepository)(repo),
+		PricingRule:   (*pricingRuleRepository)(repo),
+		Voucher:       (*voucherRepository)(repo),
+		Refund:        (*refundRepository)(repo),
+		Idempotency:   (*idempotencyKeyRepository)(repo),
+		StatusHistory: (*statusHistoryRepository)(repo),
+		Waitlist:      (*waitlistRepository)(repo),
+		Notification:  (*notificationRepository)(repo),
+		Credit:        (*creditRepository)(repo),
+		Order:         (*orderRepository)(repo),
+		Share:         (*shareRepository)(repo),
+		options:       opts,
 	}
 
 	return m
 }
+
+// Transaction runs fn with repositories bound to a single database
+// transaction. It is committed when fn returns nil and rolled back otherwise.
+func (m *Main) Transaction(ctx context.Context, fn func(tx *Main) error) error {
+	return m.options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(Init(Options{Postgres: tx, Config: m.options.Config}))
+	})
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	Options Options
+}
+
+type NotificationInterface interface {
+	CreateNotification(ctx context.Context, notification models.Notification) (models.Notification, error)
+	GetUserNotifications(ctx context.Context, userID string, unreadOnly bool, page models.PageRequest) ([]models.Notification, *models.Pagination, error)
+	MarkRead(ctx context.Context, userID, id string, now time.Time) error
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, notification models.Notification) (models.Notification, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&notification).Error
+	if err != nil {
+		return notification, customerror.NewInternalServiceError(err.Error())
+	}
+	return notification, nil
+}
+
+// GetUserNotifications pages through the notifications of a user, latest
+// first.
+func (r *notificationRepository) GetUserNotifications(ctx context.Context, userID string, unreadOnly bool, page models.PageRequest) ([]models.Notification, *models.Pagination, error) {
+	query := r.Options.Postgres.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.Notification
+	pagination, err := paginate(query, page, map[string]string{"created_at": "created_at"}, "-created_at", &notifications)
+	return notifications, pagination, err
+}
+
+// MarkRead marks a notification of the user as read; one already read keeps
+// its first read time.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id string, now time.Time) error {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", now))
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrNotificationNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+)
+
+type orderRepository struct {
+	Options Options
+}
+
+type OrderInterface interface {
+	CreateOrder(ctx context.Context, order models.Order) (models.Order, error)
+	GetOpenOrder(ctx context.Context, userID string) (models.Order, error)
+	GetOrderByID(ctx context.Context, id string) (models.Order, error)
+	UpdateOrderStatus(ctx context.Context, id, from, to string) (bool, error)
+}
+
+// CreateOrder inserts the order. A user who already has an open order, e.g.
+// created by a concurrent request, gets a Conflict error.
+func (r *orderRepository) CreateOrder(ctx context.Context, order models.Order) (models.Order, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&order).Error
+	if err != nil {
+		if pgErrorCode(err) == pgUniqueViolation {
+			return order, customerror.NewConflictError(constants.ErrCartNotFound)
+		}
+		return order, customerror.NewInternalServiceError(err.Error())
+	}
+	return order, nil
+}
+
+// GetOpenOrder returns the open order of the user, whether or not its hold
+// has passed.
+func (r *orderRepository) GetOpenOrder(ctx context.Context, userID string) (models.Order, error) {
+	var order models.Order
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, constants.ORDER_STATUS_OPEN).
+		First(&order).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return order, customerror.NewNotFoundError(constants.ErrCartNotFound)
+		}
+		return order, customerror.NewInternalServiceError(err.Error())
+	}
+	return order, nil
+}
+
+func (r *orderRepository) GetOrderByID(ctx context.Context, id string) (models.Order, error) {
+	var order models.Order
+	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).First(&order).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return order, customerror.NewNotFoundError(constants.ErrCartNotFound)
+		}
+		return order, customerror.NewInternalServiceError(err.Error())
+	}
+	return order, nil
+}
+
+// UpdateOrderStatus moves the order from one status to the other, and
+// reports whether it was still in the first.
+func (r *orderRepository) UpdateOrderStatus(ctx context.Context, id, from, to string) (bool, error) {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+)
+
+// paginate counts the rows matched by query and loads the requested page of
+// them into dest. Sort keys must be listed in columns, which maps the public
+// key to its column; defaultSort applies when none is given and the id
+// breaks ties so pages never overlap.
+func paginate(query *gorm.DB, page models.PageRequest, columns map[string]string, defaultSort string, dest interface{}) (*models.Pagination, error) {
+	order, err := orderBy(page.Sort, columns, defaultSort)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Page < 1 {
+		page.Page = 1
+	}
+	if page.PageSize < 1 {
+		page.PageSize = constants.DEFAULT_PAGE_SIZE
+	}
+	if page.PageSize > constants.MAX_PAGE_SIZE {
+		page.PageSize = constants.MAX_PAGE_SIZE
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+
+	err = query.Order(order).
+		Offset((page.Page - 1) * page.PageSize).
+		Limit(page.PageSize).
+		Find(dest).Error
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+
+	return &models.Pagination{
+		Page:      page.Page,
+		PageSize:  page.PageSize,
+		Total:     int(total),
+		TotalPage: int((total + int64(page.PageSize) - 1) / int64(page.PageSize)),
+	}, nil
+}
+
+// orderBy turns "price_per_hour,-created_at" into an ORDER BY clause.
+func orderBy(value string, columns map[string]string, defaultSort string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultSort
+	}
+
+	var clauses []string
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		direction := "ASC"
+		if strings.HasPrefix(key, "-") {
+			key, direction = key[1:], "DESC"
+		}
+
+		column, ok := columns[key]
+		if !ok {
+			keys := make([]string, 0, len(columns))
+			for k := range columns {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return "", customerror.NewBadRequestErrorf(constants.ErrInvalidSort, key, strings.Join(keys, ", "))
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+
+	return strings.Join(append(clauses, "id ASC"), ", "), nil
+}
+
+// containsPattern builds an ILIKE pattern matching value anywhere, with the
+// LIKE wildcards in value taken literally.
+func containsPattern(value string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value) + "%"
+}
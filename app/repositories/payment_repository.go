@@ -2,11 +2,15 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"take-home-test/app/constants"
 	"take-home-test/app/models"
+	"take-home-test/app/statemachine"
 	"take-home-test/pkg/customerror"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type paymentRepository struct {
@@ -17,10 +21,19 @@ type paymentRepository struct {
 type PaymentInterface interface {
 	CreatePayment(ctx context.Context, payment models.Payment) (models.Payment, error)
 	GetPaymentByBookingID(ctx context.Context, bookingID string) (models.Payment, error)
+	GetPaymentsByBookingIDs(ctx context.Context, bookingIDs []string) ([]models.Payment, error)
 	GetPaymentByID(ctx context.Context, id string) (models.Payment, error)
-	UpdatePaymentStatus(ctx context.Context, id string, status string) error
 	UpdatePaymentMethod(ctx context.Context, id string, paymentMethod string) error // ✅ ADDED
-	ProcessPayment(ctx context.Context, id string) error
+	UpdateAmounts(ctx context.Context, id string, originalAmount, amount int) error
+	SplitPayment(ctx context.Context, id string, deadline time.Time) (bool, error)
+	CreateNotification(ctx context.Context, notification models.PaymentNotification) (models.PaymentNotification, bool, error)
+	DeleteNotification(ctx context.Context, id string) error
+	SettlePayment(ctx context.Context, id string, change models.StatusChange, paidAmount int, paymentMethod string) error
+	CreateAttempt(ctx context.Context, attempt models.PaymentAttempt) (models.PaymentAttempt, error)
+	GetAttemptByOrderID(ctx context.Context, orderID string) (models.PaymentAttempt, error)
+	GetAttemptsByPaymentID(ctx context.Context, paymentID string) ([]models.PaymentAttempt, error)
+	UpdateAttempt(ctx context.Context, id string, updates map[string]interface{}) error
+	AddRefund(ctx context.Context, paymentID, attemptID string, amount int, actor models.Actor, reason string) error
 }
 
 func (r *paymentRepository) CreatePayment(ctx context.Context, payment models.Payment) (models.Payment, error) {
@@ -28,9 +41,14 @@ func (r *paymentRepository) CreatePayment(ctx context.Context, payment models.Pa
 	return payment, err
 }
 
+// GetPaymentByBookingID returns the payment of the booking, which for a
+// booking of a series or an order is the series' or order's payment.
 func (r *paymentRepository) GetPaymentByBookingID(ctx context.Context, bookingID string) (models.Payment, error) {
 	var payment models.Payment
-	err := r.Options.Postgres.WithContext(ctx).Where("booking_id = ?", bookingID).First(&payment).Error
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("booking_id = ? OR series_id = (SELECT series_id FROM bookings WHERE id = ?) OR order_id = (SELECT order_id FROM bookings WHERE id = ?)",
+			bookingID, bookingID, bookingID).
+		First(&payment).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -41,6 +59,21 @@ func (r *paymentRepository) GetPaymentByBookingID(ctx context.Context, bookingID
 	return payment, nil
 }
 
+// GetPaymentsByBookingIDs returns the payments of the bookings, including the
+// payments of the series and orders they belong to.
+func (r *paymentRepository) GetPaymentsByBookingIDs(ctx context.Context, bookingIDs []string) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("booking_id IN ? OR series_id IN (SELECT series_id FROM bookings WHERE id IN ?) OR order_id IN (SELECT order_id FROM bookings WHERE id IN ?)",
+			bookingIDs, bookingIDs, bookingIDs).
+		Find(&payments).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return payments, nil
+}
+
 func (r *paymentRepository) GetPaymentByID(ctx context.Context, id string) (models.Payment, error) {
 	var payment models.Payment
 	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).First(&payment).Error
@@ -54,10 +87,10 @@ func (r *paymentRepository) GetPaymentByID(ctx context.Context, id string) (mode
 	return payment, nil
 }
 
-func (r *paymentRepository) UpdatePaymentStatus(ctx context.Context, id string, status string) error {
+func (r *paymentRepository) UpdatePaymentMethod(ctx context.Context, id string, paymentMethod string) error {
 	result := r.Options.Postgres.WithContext(ctx).Model(&models.Payment{}).
 		Where("id = ?", id).
-		Update("status", status)
+		Update("payment_method", paymentMethod)
 
 	if result.Error != nil {
 		return customerror.NewInternalServiceError(result.Error.Error())
@@ -70,10 +103,15 @@ func (r *paymentRepository) UpdatePaymentStatus(ctx context.Context, id string,
 	return nil
 }
 
-func (r *paymentRepository) UpdatePaymentMethod(ctx context.Context, id string, paymentMethod string) error {
+// UpdateAmounts reprices the payment, e.g. when its booking is rescheduled.
+func (r *paymentRepository) UpdateAmounts(ctx context.Context, id string, originalAmount, amount int) error {
 	result := r.Options.Postgres.WithContext(ctx).Model(&models.Payment{}).
 		Where("id = ?", id).
-		Update("payment_method", paymentMethod)
+		Updates(map[string]interface{}{
+			"original_amount": originalAmount,
+			"amount":          amount,
+			"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+		})
 
 	if result.Error != nil {
 		return customerror.NewInternalServiceError(result.Error.Error())
@@ -86,20 +124,193 @@ func (r *paymentRepository) UpdatePaymentMethod(ctx context.Context, id string,
 	return nil
 }
 
-func (r *paymentRepository) ProcessPayment(ctx context.Context, id string) error {
+// SplitPayment marks the payment as split until deadline, and reports
+// whether it was still unpaid and not split yet. The shares pay the full
+// amount, so any deposit is dropped.
+func (r *paymentRepository) SplitPayment(ctx context.Context, id string, deadline time.Time) (bool, error) {
 	result := r.Options.Postgres.WithContext(ctx).Model(&models.Payment{}).
-		Where("id = ?", id).
+		Where("id = ? AND split_deadline IS NULL AND paid_amount = 0", id).
 		Updates(map[string]interface{}{
-			"status":  constants.PAYMENT_STATUS_SUCCESS,
-			"paid_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			"split_deadline": deadline,
+			"deposit_amount": 0,
+			"updated_at":     gorm.Expr("CURRENT_TIMESTAMP"),
 		})
 
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateNotification stores a notification unless one with the same key was
+// already stored; the returned bool is false for such duplicates.
+func (r *paymentRepository) CreateNotification(ctx context.Context, notification models.PaymentNotification) (models.PaymentNotification, bool, error) {
+	result := r.Options.Postgres.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "notification_key"}}, DoNothing: true}).
+		Create(&notification)
+
+	if result.Error != nil {
+		return notification, false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	return notification, result.RowsAffected == 1, nil
+}
+
+func (r *paymentRepository) DeleteNotification(ctx context.Context, id string) error {
+	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).Delete(&models.PaymentNotification{}).Error
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+// SettlePayment stores the aggregate state derived from the payment's
+// attempts when the payment state machine allows the status change; a
+// refused change is a BadRequest. paid_at is set the first time the payment
+// succeeds.
+func (r *paymentRepository) SettlePayment(ctx context.Context, id string, change models.StatusChange, paidAmount int, paymentMethod string) error {
+	updates := map[string]interface{}{
+		"paid_amount": paidAmount,
+	}
+	if paymentMethod != "" {
+		updates["payment_method"] = paymentMethod
+	}
+	if change.To == constants.PAYMENT_STATUS_SUCCESS {
+		updates["paid_at"] = gorm.Expr("COALESCE(paid_at, CURRENT_TIMESTAMP)")
+	}
+
+	from, applied, err := transition(r.Options.Postgres.WithContext(ctx), &models.Payment{}, statemachine.Payment, id, change, updates)
+	if err != nil {
+		return err
+	}
+
+	if from == "" {
+		return customerror.NewNotFoundErrorf(constants.ErrPaymentNotFound, id)
+	}
+
+	if !applied {
+		return statemachine.Payment.Check(from, change.To)
+	}
+
+	return nil
+}
+
+// CreateAttempt stores a new attempt of the payment under the payment's next
+// order ID, "<booking id>-<sequence>". The payment row stays locked while the
+// sequence is taken, so concurrent attempts never get the same order ID.
+func (r *paymentRepository) CreateAttempt(ctx context.Context, attempt models.PaymentAttempt) (models.PaymentAttempt, error) {
+	err := r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var payment models.Payment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", attempt.PaymentID).First(&payment).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return customerror.NewNotFoundErrorf(constants.ErrPaymentNotFound, attempt.PaymentID)
+			}
+			return customerror.NewInternalServiceError(err.Error())
+		}
+
+		var count int64
+		if err := tx.Model(&models.PaymentAttempt{}).Where("payment_id = ?", attempt.PaymentID).Count(&count).Error; err != nil {
+			return customerror.NewInternalServiceError(err.Error())
+		}
+
+		attempt.OrderID = fmt.Sprintf("%s-%d", attempt.BookingID, count+1)
+		if err := tx.Create(&attempt).Error; err != nil {
+			return customerror.NewInternalServiceError(err.Error())
+		}
+		return nil
+	})
+	return attempt, err
+}
+
+func (r *paymentRepository) GetAttemptByOrderID(ctx context.Context, orderID string) (models.PaymentAttempt, error) {
+	var attempt models.PaymentAttempt
+	err := r.Options.Postgres.WithContext(ctx).Where("order_id = ?", orderID).First(&attempt).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return attempt, customerror.NewNotFoundErrorf(constants.ErrPaymentAttemptNotFound, orderID)
+		}
+		return attempt, customerror.NewInternalServiceError(err.Error())
+	}
+	return attempt, nil
+}
+
+func (r *paymentRepository) GetAttemptsByPaymentID(ctx context.Context, paymentID string) ([]models.PaymentAttempt, error) {
+	var attempts []models.PaymentAttempt
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at ASC").
+		Find(&attempts).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return attempts, nil
+}
+
+// AddRefund records a refunded amount on an attempt and its payment in one
+// transaction, moving the payment to refunded or partially_refunded.
+func (r *paymentRepository) AddRefund(ctx context.Context, paymentID, attemptID string, amount int, actor models.Actor, reason string) error {
+	return r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PaymentAttempt{}).
+			Where("id = ? AND refunded_amount + ? <= amount", attemptID, amount).
+			Updates(map[string]interface{}{
+				"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+				"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if result.Error != nil {
+			return customerror.NewInternalServiceError(result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return customerror.NewNotFoundErrorf(constants.ErrPaymentAttemptNotFound, attemptID)
+		}
+
+		var payment models.Payment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", paymentID).First(&payment).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return customerror.NewNotFoundErrorf(constants.ErrPaymentNotFound, paymentID)
+			}
+			return customerror.NewInternalServiceError(err.Error())
+		}
+
+		change := models.StatusChange{
+			To:     constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+			Actor:  actor,
+			Reason: reason,
+		}
+		if payment.RefundedAmount+amount >= payment.PaidAmount {
+			change.To = constants.PAYMENT_STATUS_REFUNDED
+		}
+
+		from, applied, err := transition(tx, &models.Payment{}, statemachine.Payment, paymentID, change, map[string]interface{}{
+			"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+		})
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return statemachine.Payment.Check(from, change.To)
+		}
+
+		return nil
+	})
+}
+
+func (r *paymentRepository) UpdateAttempt(ctx context.Context, id string, updates map[string]interface{}) error {
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.PaymentAttempt{}).
+		Where("id = ?", id).
+		Updates(updates)
+
 	if result.Error != nil {
 		return customerror.NewInternalServiceError(result.Error.Error())
 	}
 
 	if result.RowsAffected == 0 {
-		return customerror.NewNotFoundErrorf(constants.ErrPaymentNotFound, id)
+		return customerror.NewNotFoundErrorf(constants.ErrPaymentAttemptNotFound, id)
 	}
 
 	return nil
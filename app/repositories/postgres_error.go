@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres SQLSTATE codes the repositories translate into domain errors.
+const (
+	pgUniqueViolation    = "23505"
+	pgExclusionViolation = "23P01"
+	pgCheckViolation     = "23514"
+)
+
+// pgErrorCode returns the SQLSTATE of a Postgres error, or "" for any other
+// error.
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
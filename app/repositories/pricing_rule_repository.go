@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+)
+
+type pricingRuleRepository struct {
+	Options Options
+}
+
+type PricingRuleInterface interface {
+	GetRulesByFieldID(ctx context.Context, fieldID string) ([]models.PricingRule, error)
+	GetRuleByID(ctx context.Context, fieldID, id string) (models.PricingRule, error)
+	CreateRule(ctx context.Context, rule models.PricingRule) (models.PricingRule, error)
+	UpdateRule(ctx context.Context, rule models.PricingRule) (models.PricingRule, error)
+	DeleteRule(ctx context.Context, fieldID, id string) error
+}
+
+func (r *pricingRuleRepository) GetRulesByFieldID(ctx context.Context, fieldID string) ([]models.PricingRule, error) {
+	var rules []models.PricingRule
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("priority DESC, start_time ASC").
+		Find(&rules).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return rules, nil
+}
+
+func (r *pricingRuleRepository) GetRuleByID(ctx context.Context, fieldID, id string) (models.PricingRule, error) {
+	var rule models.PricingRule
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("id = ? AND field_id = ?", id, fieldID).
+		First(&rule).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return rule, customerror.NewNotFoundErrorf(constants.ErrPricingRuleNotFound, id)
+		}
+		return rule, customerror.NewInternalServiceError(err.Error())
+	}
+	return rule, nil
+}
+
+func (r *pricingRuleRepository) CreateRule(ctx context.Context, rule models.PricingRule) (models.PricingRule, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&rule).Error
+	if err != nil {
+		return rule, customerror.NewInternalServiceError(err.Error())
+	}
+	return rule, nil
+}
+
+func (r *pricingRuleRepository) UpdateRule(ctx context.Context, rule models.PricingRule) (models.PricingRule, error) {
+	err := r.Options.Postgres.WithContext(ctx).Save(&rule).Error
+	if err != nil {
+		return rule, customerror.NewInternalServiceError(err.Error())
+	}
+	return rule, nil
+}
+
+func (r *pricingRuleRepository) DeleteRule(ctx context.Context, fieldID, id string) error {
+	result := r.Options.Postgres.WithContext(ctx).
+		Where("id = ? AND field_id = ?", id, fieldID).
+		Delete(&models.PricingRule{})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrPricingRuleNotFound, id)
+	}
+
+	return nil
+}
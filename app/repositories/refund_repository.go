@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type refundRepository struct {
+	Options Options
+}
+
+type RefundInterface interface {
+	CreateRefund(ctx context.Context, refund models.Refund) (models.Refund, error)
+	GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]models.Refund, error)
+	GetRefundByKey(ctx context.Context, refundKey string) (models.Refund, error)
+	CompleteRefund(ctx context.Context, id string, gatewayRefundID string) (bool, error)
+	FailRefund(ctx context.Context, id string, failureReason string) error
+}
+
+// CreateRefund stores a pending refund. A failed refund with the same key is
+// taken over so it can be retried; any other refund with the key is a
+// Conflict.
+func (r *refundRepository) CreateRefund(ctx context.Context, refund models.Refund) (models.Refund, error) {
+	refund.Status = constants.REFUND_STATUS_PENDING
+
+	result := r.Options.Postgres.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "refund_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"amount":         clause.Column{Table: "excluded", Name: "amount"},
+				"reason":         clause.Column{Table: "excluded", Name: "reason"},
+				"source":         clause.Column{Table: "excluded", Name: "source"},
+				"requested_by":   clause.Column{Table: "excluded", Name: "requested_by"},
+				"status":         constants.REFUND_STATUS_PENDING,
+				"failure_reason": "",
+				"updated_at":     clause.Expr{SQL: "CURRENT_TIMESTAMP"},
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: "refunds", Name: "status"}, Value: constants.REFUND_STATUS_FAILED},
+			}},
+		}).
+		Create(&refund)
+
+	if result.Error != nil {
+		return refund, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return refund, customerror.NewConflictErrorf(constants.ErrRefundInProgress, refund.RefundKey)
+	}
+	return refund, nil
+}
+
+func (r *refundRepository) GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]models.Refund, error) {
+	var refunds []models.Refund
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at ASC").
+		Find(&refunds).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return refunds, nil
+}
+
+func (r *refundRepository) GetRefundByKey(ctx context.Context, refundKey string) (models.Refund, error) {
+	var refund models.Refund
+	err := r.Options.Postgres.WithContext(ctx).Where("refund_key = ?", refundKey).First(&refund).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return refund, customerror.NewNotFoundErrorf(constants.ErrRefundNotFound, refundKey)
+		}
+		return refund, customerror.NewInternalServiceError(err.Error())
+	}
+	return refund, nil
+}
+
+// CompleteRefund marks a pending or failed refund successful. It returns
+// false when the refund was already completed, so the caller books the
+// refunded amount only once.
+func (r *refundRepository) CompleteRefund(ctx context.Context, id string, gatewayRefundID string) (bool, error) {
+	updates := map[string]interface{}{
+		"status":         constants.REFUND_STATUS_SUCCESS,
+		"failure_reason": "",
+		"updated_at":     gorm.Expr("CURRENT_TIMESTAMP"),
+	}
+	if gatewayRefundID != "" {
+		updates["gateway_refund_id"] = gatewayRefundID
+	}
+
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Refund{}).
+		Where("id = ? AND status <> ?", id, constants.REFUND_STATUS_SUCCESS).
+		Updates(updates)
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *refundRepository) FailRefund(ctx context.Context, id string, failureReason string) error {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Refund{}).
+		Where("id = ? AND status = ?", id, constants.REFUND_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"status":         constants.REFUND_STATUS_FAILED,
+			"failure_reason": failureReason,
+			"updated_at":     gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrRefundNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessionRepository struct {
+	Options Options
+}
+
+type SessionInterface interface {
+	CreateSession(ctx context.Context, session models.UserSession) (models.UserSession, error)
+	GetSessionByID(ctx context.Context, id string) (models.UserSession, error)
+	RevokeSession(ctx context.Context, id string) error
+	RevokeUserSessions(ctx context.Context, userID string) error
+	IsSessionRevoked(ctx context.Context, id string) (bool, error)
+	CreateRefreshToken(ctx context.Context, token models.RefreshToken) (models.RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string) (bool, error)
+	RevokeToken(ctx context.Context, token models.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session models.UserSession) (models.UserSession, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&session).Error
+	return session, err
+}
+
+func (r *sessionRepository) GetSessionByID(ctx context.Context, id string) (models.UserSession, error) {
+	var session models.UserSession
+	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).First(&session).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return session, customerror.NewUnauthorizedError(constants.ErrSessionRevoked)
+		}
+		return session, customerror.NewInternalServiceError(err.Error())
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) RevokeSession(ctx context.Context, id string) error {
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+func (r *sessionRepository) RevokeUserSessions(ctx context.Context, userID string) error {
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+func (r *sessionRepository) IsSessionRevoked(ctx context.Context, id string) (bool, error) {
+	var count int64
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, time.Now()).
+		Count(&count).Error
+
+	if err != nil {
+		return false, customerror.NewInternalServiceError(err.Error())
+	}
+	return count == 0, nil
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, token models.RefreshToken) (models.RefreshToken, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&token).Error
+	return token, err
+}
+
+func (r *sessionRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.Options.Postgres.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return token, customerror.NewUnauthorizedError(constants.ErrInvalidRefresh)
+		}
+		return token, customerror.NewInternalServiceError(err.Error())
+	}
+	return token, nil
+}
+
+// MarkRefreshTokenUsed consumes a refresh token. It reports false when the
+// token had already been used, which callers treat as token reuse.
+func (r *sessionRepository) MarkRefreshTokenUsed(ctx context.Context, id string) (bool, error) {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", gorm.Expr("CURRENT_TIMESTAMP"))
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *sessionRepository) RevokeToken(ctx context.Context, token models.RevokedToken) error {
+	err := r.Options.Postgres.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&token).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+func (r *sessionRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.RevokedToken{}).
+		Where("jti = ?", jti).
+		Count(&count).Error
+
+	if err != nil {
+		return false, customerror.NewInternalServiceError(err.Error())
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"gorm.io/gorm"
+)
+
+type shareRepository struct {
+	Options Options
+}
+
+type ShareInterface interface {
+	CreateShares(ctx context.Context, shares []models.PaymentShare) ([]models.PaymentShare, error)
+	GetShareByID(ctx context.Context, id string) (models.PaymentShare, error)
+	GetSharesByPaymentID(ctx context.Context, paymentID string) ([]models.PaymentShare, error)
+	GetSharesByEmail(ctx context.Context, email string) ([]models.PaymentShare, error)
+	MarkSharePaid(ctx context.Context, id string) error
+	CloseShares(ctx context.Context, paymentID, status string) error
+}
+
+func (r *shareRepository) CreateShares(ctx context.Context, shares []models.PaymentShare) ([]models.PaymentShare, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&shares).Error
+	if err != nil {
+		return shares, customerror.NewInternalServiceError(err.Error())
+	}
+	return shares, nil
+}
+
+func (r *shareRepository) GetShareByID(ctx context.Context, id string) (models.PaymentShare, error) {
+	var share models.PaymentShare
+	err := r.Options.Postgres.WithContext(ctx).Where("id = ?", id).First(&share).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return share, customerror.NewNotFoundErrorf(constants.ErrShareNotFound, id)
+		}
+		return share, customerror.NewInternalServiceError(err.Error())
+	}
+	return share, nil
+}
+
+func (r *shareRepository) GetSharesByPaymentID(ctx context.Context, paymentID string) ([]models.PaymentShare, error) {
+	var shares []models.PaymentShare
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at ASC, email ASC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return shares, nil
+}
+
+// GetSharesByEmail lists the shares to be paid by the email, latest first.
+func (r *shareRepository) GetSharesByEmail(ctx context.Context, email string) ([]models.PaymentShare, error) {
+	var shares []models.PaymentShare
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("email = ?", email).
+		Order("created_at DESC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return shares, nil
+}
+
+// MarkSharePaid marks a pending share as paid. A share closed meanwhile is
+// left as it is.
+func (r *shareRepository) MarkSharePaid(ctx context.Context, id string) error {
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.PaymentShare{}).
+		Where("id = ? AND status = ?", id, constants.SHARE_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"status":     constants.SHARE_STATUS_PAID,
+			"paid_at":    gorm.Expr("CURRENT_TIMESTAMP"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
+
+// CloseShares moves the payment's pending shares to status, e.g. covered
+// once the owner paid the rest.
+func (r *shareRepository) CloseShares(ctx context.Context, paymentID, status string) error {
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.PaymentShare{}).
+		Where("payment_id = ? AND status = ?", paymentID, constants.SHARE_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/models"
+	"take-home-test/app/statemachine"
+	"take-home-test/pkg/customerror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type statusHistoryRepository struct {
+	Options Options
+}
+
+type StatusHistoryInterface interface {
+	GetStatusHistory(ctx context.Context, entityIDs []string) ([]models.StatusHistory, error)
+}
+
+// GetStatusHistory lists the transitions of the given bookings and payments,
+// oldest first.
+func (r *statusHistoryRepository) GetStatusHistory(ctx context.Context, entityIDs []string) ([]models.StatusHistory, error) {
+	var history []models.StatusHistory
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("entity_id IN ?", entityIDs).
+		Order("created_at ASC").
+		Find(&history).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return history, nil
+}
+
+// transition moves the row of model with id to change.To, applying updates
+// alongside, when machine allows it from the row's current status, and
+// records the change in status_history. The row is locked first so
+// concurrent transitions run one after the other. It returns the status the
+// row had, empty when there is no such row, and whether the change was
+// applied. Staying in the same status is not recorded.
+func transition(db *gorm.DB, model interface{}, machine statemachine.Machine, id string, change models.StatusChange, updates map[string]interface{}) (string, bool, error) {
+	entityID, err := uuid.Parse(id)
+	if err != nil {
+		return "", false, nil
+	}
+
+	var (
+		from    string
+		applied bool
+	)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var current struct{ Status string }
+		result := tx.Model(model).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("status").
+			Where("id = ?", id).
+			Limit(1).
+			Find(&current)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		from = current.Status
+		if !machine.Can(from, change.To) {
+			return nil
+		}
+
+		values := map[string]interface{}{
+			"status":     change.To,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}
+		for column, value := range updates {
+			values[column] = value
+		}
+		if err := tx.Model(model).Where("id = ? AND status = ?", id, from).Updates(values).Error; err != nil {
+			return err
+		}
+		applied = true
+
+		if from == change.To {
+			return nil
+		}
+		return tx.Create(&models.StatusHistory{
+			EntityType: machine.Name(),
+			EntityID:   entityID,
+			FromStatus: from,
+			ToStatus:   change.To,
+			Source:     change.Actor.Source,
+			ActorID:    change.Actor.ID,
+			Reason:     change.Reason,
+		}).Error
+	})
+
+	if err != nil {
+		return from, false, customerror.NewInternalServiceError(err.Error())
+	}
+	return from, applied, nil
+}
@@ -17,7 +17,10 @@ type UserInterface interface {
 	CreateUser(ctx context.Context, user models.User) (models.User, error)
 	FindByEmail(ctx context.Context, email string) (models.User, error)
 	FindByID(ctx context.Context, id string) (models.User, error)
+	FindByIDs(ctx context.Context, ids []string) ([]models.User, error)
 	IsEmailExist(ctx context.Context, email string) (bool, error)
+	UpdateUserRole(ctx context.Context, id string, role string) error
+	CountUsersByRole(ctx context.Context, role string) (int64, error)
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user models.User) (models.User, error) {
@@ -51,6 +54,42 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (models.User,
 	return user, nil
 }
 
+// FindByIDs loads the users with the given IDs; unknown IDs are skipped.
+func (r *userRepository) FindByIDs(ctx context.Context, ids []string) ([]models.User, error) {
+	var users []models.User
+	err := r.Options.Postgres.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return users, nil
+}
+
+func (r *userRepository) UpdateUserRole(ctx context.Context, id string, role string) error {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"role":       role,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return customerror.NewInternalServiceError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return customerror.NewNotFoundErrorf(constants.ErrUserNotFoundByID, id)
+	}
+
+	return nil
+}
+
+func (r *userRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.User{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
 func (r *userRepository) IsEmailExist(ctx context.Context, email string) (bool, error) {
 	var count int64
 	err := r.Options.Postgres.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type voucherRepository struct {
+	Options Options
+}
+
+type VoucherInterface interface {
+	GetVouchers(ctx context.Context) ([]models.Voucher, error)
+	GetVoucherByID(ctx context.Context, id string) (models.Voucher, error)
+	GetVoucherByCode(ctx context.Context, code string) (models.Voucher, error)
+	CreateVoucher(ctx context.Context, voucher models.Voucher) (models.Voucher, error)
+	UpdateVoucher(ctx context.Context, voucher models.Voucher) (models.Voucher, error)
+	IncrementUsage(ctx context.Context, id uuid.UUID) (bool, error)
+	CountUserRedemptions(ctx context.Context, voucherID, userID uuid.UUID) (int64, error)
+	CreateRedemption(ctx context.Context, redemption models.VoucherRedemption) (models.VoucherRedemption, error)
+	ReleaseRedemption(ctx context.Context, bookingID string) error
+}
+
+func (r *voucherRepository) GetVouchers(ctx context.Context) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	err := r.Options.Postgres.WithContext(ctx).Order("created_at DESC").Find(&vouchers).Error
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+
+	if err := r.loadFieldIDs(ctx, vouchers); err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *voucherRepository) GetVoucherByID(ctx context.Context, id string) (models.Voucher, error) {
+	return r.getVoucher(ctx, id, "id = ?", id)
+}
+
+func (r *voucherRepository) GetVoucherByCode(ctx context.Context, code string) (models.Voucher, error) {
+	return r.getVoucher(ctx, code, "code = ?", code)
+}
+
+func (r *voucherRepository) getVoucher(ctx context.Context, key string, query string, args ...interface{}) (models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.Options.Postgres.WithContext(ctx).Where(query, args...).First(&voucher).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return voucher, customerror.NewNotFoundErrorf(constants.ErrVoucherNotFound, key)
+		}
+		return voucher, customerror.NewInternalServiceError(err.Error())
+	}
+
+	vouchers := []models.Voucher{voucher}
+	if err := r.loadFieldIDs(ctx, vouchers); err != nil {
+		return voucher, err
+	}
+	return vouchers[0], nil
+}
+
+// loadFieldIDs fills the field restrictions of the vouchers.
+func (r *voucherRepository) loadFieldIDs(ctx context.Context, vouchers []models.Voucher) error {
+	if len(vouchers) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		ids = append(ids, voucher.ID)
+	}
+
+	var rows []models.VoucherField
+	if err := r.Options.Postgres.WithContext(ctx).Where("voucher_id IN ?", ids).Find(&rows).Error; err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+
+	byVoucher := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range rows {
+		byVoucher[row.VoucherID] = append(byVoucher[row.VoucherID], row.FieldID)
+	}
+	for i := range vouchers {
+		vouchers[i].FieldIDs = byVoucher[vouchers[i].ID]
+	}
+	return nil
+}
+
+// CreateVoucher inserts the voucher with its field restrictions. A code that
+// is already taken is reported as a Conflict error.
+func (r *voucherRepository) CreateVoucher(ctx context.Context, voucher models.Voucher) (models.Voucher, error) {
+	err := r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&voucher).Error; err != nil {
+			return err
+		}
+		return replaceVoucherFields(tx, voucher)
+	})
+
+	if err != nil {
+		if pgErrorCode(err) == pgUniqueViolation {
+			return voucher, customerror.NewConflictErrorf(constants.ErrDuplicateVoucherCode, voucher.Code)
+		}
+		return voucher, customerror.NewInternalServiceError(err.Error())
+	}
+	return voucher, nil
+}
+
+// UpdateVoucher saves the voucher and replaces its field restrictions. The
+// usage counter is left alone so concurrent redemptions are not lost; a
+// usage_limit that a redemption made meanwhile pushed below used_count is
+// rejected by the vouchers_used_count_check constraint as a BadRequest error.
+func (r *voucherRepository) UpdateVoucher(ctx context.Context, voucher models.Voucher) (models.Voucher, error) {
+	err := r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("used_count", "created_at").Save(&voucher).Error; err != nil {
+			return err
+		}
+		return replaceVoucherFields(tx, voucher)
+	})
+
+	if err != nil {
+		switch pgErrorCode(err) {
+		case pgUniqueViolation:
+			return voucher, customerror.NewConflictErrorf(constants.ErrDuplicateVoucherCode, voucher.Code)
+		case pgCheckViolation:
+			return voucher, customerror.NewBadRequestErrorf(constants.ErrUsageLimitBelowUsed, voucher.UsedCount)
+		}
+		return voucher, customerror.NewInternalServiceError(err.Error())
+	}
+	return voucher, nil
+}
+
+func replaceVoucherFields(tx *gorm.DB, voucher models.Voucher) error {
+	if err := tx.Where("voucher_id = ?", voucher.ID).Delete(&models.VoucherField{}).Error; err != nil {
+		return err
+	}
+	if len(voucher.FieldIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]models.VoucherField, 0, len(voucher.FieldIDs))
+	for _, fieldID := range voucher.FieldIDs {
+		rows = append(rows, models.VoucherField{VoucherID: voucher.ID, FieldID: fieldID})
+	}
+	return tx.Create(&rows).Error
+}
+
+// IncrementUsage counts one more use of the voucher unless its usage limit
+// is reached, and reports whether it did. The row stays locked until the
+// surrounding transaction ends, which serializes redemptions of a voucher.
+func (r *voucherRepository) IncrementUsage(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.Voucher{}).
+		Where("id = ? AND (usage_limit IS NULL OR used_count < usage_limit)", id).
+		Updates(map[string]interface{}{
+			"used_count": gorm.Expr("used_count + 1"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *voucherRepository) CountUserRedemptions(ctx context.Context, voucherID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.Options.Postgres.WithContext(ctx).Model(&models.VoucherRedemption{}).
+		Where("voucher_id = ? AND user_id = ?", voucherID, userID).
+		Count(&count).Error
+
+	if err != nil {
+		return 0, customerror.NewInternalServiceError(err.Error())
+	}
+	return count, nil
+}
+
+func (r *voucherRepository) CreateRedemption(ctx context.Context, redemption models.VoucherRedemption) (models.VoucherRedemption, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&redemption).Error
+	if err != nil {
+		return redemption, customerror.NewInternalServiceError(err.Error())
+	}
+	return redemption, nil
+}
+
+// ReleaseRedemption gives back the voucher use of a booking that will not
+// take place. Bookings without a voucher are ignored.
+func (r *voucherRepository) ReleaseRedemption(ctx context.Context, bookingID string) error {
+	err := r.Options.Postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var redemption models.VoucherRedemption
+		result := tx.Where("booking_id = ?", bookingID).Limit(1).Find(&redemption)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		deleted := tx.Delete(&models.VoucherRedemption{}, "id = ?", redemption.ID)
+		if deleted.Error != nil || deleted.RowsAffected == 0 {
+			// Already released by a concurrent call
+			return deleted.Error
+		}
+
+		return tx.Model(&models.Voucher{}).
+			Where("id = ? AND used_count > 0", redemption.VoucherID).
+			Updates(map[string]interface{}{
+				"used_count": gorm.Expr("used_count - 1"),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+	})
+
+	if err != nil {
+		return customerror.NewInternalServiceError(err.Error())
+	}
+	return nil
+}
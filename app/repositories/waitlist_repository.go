@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type waitlistRepository struct {
+	Options Options
+}
+
+type WaitlistInterface interface {
+	CreateEntry(ctx context.Context, entry models.WaitlistEntry) (models.WaitlistEntry, error)
+	GetEntryByID(ctx context.Context, id string) (models.WaitlistEntry, error)
+	GetEntryByBookingID(ctx context.Context, bookingID string) (models.WaitlistEntry, error)
+	GetUserEntries(ctx context.Context, userID string) ([]models.WaitlistEntry, error)
+	GetWaitingEntries(ctx context.Context, fieldID string, from, to, now time.Time) ([]models.WaitlistEntry, error)
+	UpdateEntry(ctx context.Context, id string, statuses []string, updates map[string]interface{}) (bool, error)
+	ExpireStaleEntries(ctx context.Context, now time.Time) (int64, error)
+}
+
+// CreateEntry inserts the entry. A user already waiting for, or offered, the
+// same slot is reported as a Conflict error.
+func (r *waitlistRepository) CreateEntry(ctx context.Context, entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+	err := r.Options.Postgres.WithContext(ctx).Create(&entry).Error
+	if err != nil {
+		if pgErrorCode(err) == pgUniqueViolation {
+			return entry, customerror.NewConflictError(constants.ErrAlreadyWaitlisted)
+		}
+		return entry, customerror.NewInternalServiceError(err.Error())
+	}
+	return entry, nil
+}
+
+func (r *waitlistRepository) GetEntryByID(ctx context.Context, id string) (models.WaitlistEntry, error) {
+	return r.getEntry(ctx, id, "id = ?", id)
+}
+
+// GetEntryByBookingID returns the entry the booking was offered to.
+func (r *waitlistRepository) GetEntryByBookingID(ctx context.Context, bookingID string) (models.WaitlistEntry, error) {
+	return r.getEntry(ctx, bookingID, "booking_id = ?", bookingID)
+}
+
+func (r *waitlistRepository) getEntry(ctx context.Context, key string, query string, args ...interface{}) (models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.Options.Postgres.WithContext(ctx).Where(query, args...).First(&entry).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entry, customerror.NewNotFoundErrorf(constants.ErrWaitlistEntryNotFound, key)
+		}
+		return entry, customerror.NewInternalServiceError(err.Error())
+	}
+	return entry, nil
+}
+
+// GetUserEntries lists the entries of a user, latest first.
+func (r *waitlistRepository) GetUserEntries(ctx context.Context, userID string) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return entries, nil
+}
+
+// GetWaitingEntries lists the waiting entries of a field that overlap
+// [from, to) and have not started by now, in the order they joined.
+func (r *waitlistRepository) GetWaitingEntries(ctx context.Context, fieldID string, from, to, now time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.Options.Postgres.WithContext(ctx).
+		Where("field_id = ? AND status = ?", fieldID, constants.WAITLIST_STATUS_WAITING).
+		Where("start_time < ? AND end_time > ? AND start_time > ?", to, from, now).
+		Order("created_at ASC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, customerror.NewInternalServiceError(err.Error())
+	}
+	return entries, nil
+}
+
+// UpdateEntry applies updates to the entry when it is in one of statuses,
+// and reports whether it was.
+func (r *waitlistRepository) UpdateEntry(ctx context.Context, id string, statuses []string, updates map[string]interface{}) (bool, error) {
+	values := map[string]interface{}{
+		"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+	}
+	for column, value := range updates {
+		values[column] = value
+	}
+
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.WaitlistEntry{}).
+		Where("id = ? AND status IN ?", id, statuses).
+		Updates(values)
+
+	if result.Error != nil {
+		return false, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExpireStaleEntries expires the waiting entries whose slot has started, and
+// returns how many there were.
+func (r *waitlistRepository) ExpireStaleEntries(ctx context.Context, now time.Time) (int64, error) {
+	result := r.Options.Postgres.WithContext(ctx).Model(&models.WaitlistEntry{}).
+		Where("status = ? AND start_time <= ?", constants.WAITLIST_STATUS_WAITING, now).
+		Updates(map[string]interface{}{
+			"status":     constants.WAITLIST_STATUS_EXPIRED,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if result.Error != nil {
+		return 0, customerror.NewInternalServiceError(result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
@@ -2,13 +2,19 @@ package routes
 
 import (
 	"take-home-test/app/controllers"
-	"take-home-test/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 )
 
-func ConfigureRouter(app *fiber.App, controller *controllers.Main) {
+// Middlewares holds the handlers that depend on application state and are
+// therefore built by the caller.
+type Middlewares struct {
+	JWT         fiber.Handler
+	Idempotency fiber.Handler
+}
+
+func ConfigureRouter(app *fiber.App, controller *controllers.Main, middlewares Middlewares) {
 	// Swagger documentation
 	app.Get("/swagger/*", swagger.New(swagger.Config{
 		URL:          "/swagger/doc.json",
@@ -24,23 +30,41 @@ func ConfigureRouter(app *fiber.App, controller *controllers.Main) {
 		{
 			public.Post("/register", controller.Auth.Register)
 			public.Post("/login", controller.Auth.Login)
+			public.Post("/refresh", controller.Auth.Refresh)
+			public.Post("/logout", middlewares.JWT, controller.Auth.Logout)
 		}
 
 		// Public Field routes (no auth required)
-		api.Get("/fields", controller.Field.GetFields)                    // Public
-		api.Get("/fields/:id", controller.Field.GetFieldByID)             // Public
+		api.Get("/fields", controller.Field.GetFields)                             // Public
+		api.Get("/fields/:id", controller.Field.GetFieldByID)                      // Public
+		api.Get("/fields/:id/availability", controller.Field.GetFieldAvailability) // Public
+		api.Get("/fields/:id/schedule", controller.Field.GetFieldSchedule)         // Public
+		api.Get("/fields/:id/pricing-rules", controller.Field.GetPricingRules)     // Public
+		api.Get("/fields/:id/quote", controller.Field.GetQuote)                    // Public
+		api.Get("/holidays", controller.Holiday.GetHolidays)                       // Public
 
 		// ✅ PUBLIC Payment routes (no auth required)
 		api.Get("/payments/:booking_id", controller.Payment.GetPaymentByBookingID) // Public - View payment
 
+		// Public payment notification (no auth required). Registered before the
+		// protected group, whose JWT middleware applies to every later /api route.
+		api.Post("/payments/notification", controller.Payment.HandlePaymentNotification)
+
+		// Offline fake payment gateway (only active when PAYMENT_GATEWAY=fake)
+		api.Get("/payments/fake/:order_id", controller.Payment.GetFakeTransaction)
+		api.Post("/payments/fake/:order_id", controller.Payment.SimulatePayment)
+
 		// Protected routes (require JWT auth)
-		protected := api.Group("", middleware.JWTMiddleware)
+		protected := api.Group("", middlewares.JWT)
 		{
 			// User routes
 			users := protected.Group("/users")
 			{
 				users.Get("/profile", controller.User.GetProfile)
+				users.Get("/credits", controller.User.GetCredits)
+				users.Get("/shares", controller.SplitPayment.GetUserShares)
 				users.Get("/:id", controller.User.GetUserByID)
+				users.Patch("/:id/role", controller.User.UpdateUserRole) // Admin only
 			}
 
 			// Protected Field routes (admin only)
@@ -49,26 +73,94 @@ func ConfigureRouter(app *fiber.App, controller *controllers.Main) {
 				fields.Post("", controller.Field.CreateField)       // Admin only
 				fields.Put("/:id", controller.Field.UpdateField)    // Admin only
 				fields.Delete("/:id", controller.Field.DeleteField) // Admin only
+
+				fields.Put("/:id/schedule", controller.Field.ReplaceFieldSchedule)                // Admin only
+				fields.Post("/:id/schedule", controller.Field.AddFieldSchedule)                   // Admin only
+				fields.Delete("/:id/schedule/:schedule_id", controller.Field.DeleteFieldSchedule) // Admin only
+
+				fields.Get("/:id/blackouts", controller.Field.GetFieldBlackouts)                                      // Admin only
+				fields.Post("/:id/blackouts", controller.Field.CreateFieldBlackout)                                   // Admin only
+				fields.Get("/:id/blackouts/:blackout_id/conflicts", controller.Field.GetBlackoutConflicts)            // Admin only
+				fields.Post("/:id/blackouts/:blackout_id/cancel-conflicts", controller.Field.CancelBlackoutConflicts) // Admin only
+				fields.Delete("/:id/blackouts/:blackout_id", controller.Field.DeleteFieldBlackout)                    // Admin only
+
+				fields.Post("/:id/pricing-rules", controller.Field.CreatePricingRule)            // Admin only
+				fields.Put("/:id/pricing-rules/:rule_id", controller.Field.UpdatePricingRule)    // Admin only
+				fields.Delete("/:id/pricing-rules/:rule_id", controller.Field.DeletePricingRule) // Admin only
+			}
+
+			// Holiday routes (admin only)
+			holidays := protected.Group("/holidays")
+			{
+				holidays.Post("", controller.Holiday.CreateHoliday)         // Admin only
+				holidays.Post("/import", controller.Holiday.ImportHolidays) // Admin only
+				holidays.Delete("/:id", controller.Holiday.DeleteHoliday)   // Admin only
+			}
+
+			// Voucher routes (admin only)
+			vouchers := protected.Group("/vouchers")
+			{
+				vouchers.Get("", controller.Voucher.GetVouchers)        // Admin only
+				vouchers.Post("", controller.Voucher.CreateVoucher)     // Admin only
+				vouchers.Get("/:id", controller.Voucher.GetVoucherByID) // Admin only
+				vouchers.Put("/:id", controller.Voucher.UpdateVoucher)  // Admin only
 			}
 
 			// Booking routes
 			bookings := protected.Group("/bookings")
 			{
-				bookings.Post("", controller.Booking.CreateBooking)
+				bookings.Post("", middlewares.Idempotency, controller.Booking.CreateBooking)
+				bookings.Post("/recurring", middlewares.Idempotency, controller.Booking.CreateRecurringBooking)
 				bookings.Get("/user", controller.Booking.GetUserBookings)
 				bookings.Get("/:id", controller.Booking.GetBookingByID)
+				bookings.Post("/:id/cancel", controller.Booking.CancelBooking)
+				bookings.Patch("/:id/reschedule", controller.Booking.RescheduleBooking)
+				bookings.Post("/:id/split", controller.SplitPayment.SplitPayment)
+				bookings.Get("/:id/split", controller.SplitPayment.GetSplitPayment)
+				bookings.Post("/:id/split/cover", middlewares.Idempotency, controller.SplitPayment.CoverRemainder)
+
+				bookings.Get("", controller.Booking.GetBookings)                                           // Admin only
+				bookings.Post("/walk-in", middlewares.Idempotency, controller.Booking.CreateWalkInBooking) // Admin only
+				bookings.Get("/:id/details", controller.Booking.GetBookingDetails)                         // Admin only
+				bookings.Post("/:id/confirm", controller.Booking.ConfirmBooking)                           // Admin only
+				bookings.Post("/:id/no-show", controller.Booking.MarkNoShow)                               // Admin only
+			}
+
+			// Waitlist routes
+			waitlist := protected.Group("/waitlist")
+			{
+				waitlist.Post("", controller.Waitlist.JoinWaitlist)
+				waitlist.Get("", controller.Waitlist.GetUserWaitlist)
+				waitlist.Delete("/:id", controller.Waitlist.LeaveWaitlist)
+				waitlist.Post("/:id/confirm", controller.Waitlist.ConfirmOffer)
+			}
+
+			// Cart routes
+			cart := protected.Group("/cart")
+			{
+				cart.Get("", controller.Cart.GetCart)
+				cart.Post("/items", controller.Cart.AddCartItem)
+				cart.Delete("/items/:booking_id", controller.Cart.RemoveCartItem)
+				cart.Post("/checkout", middlewares.Idempotency, controller.Cart.Checkout)
+			}
+
+			// Notification routes
+			notifications := protected.Group("/notifications")
+			{
+				notifications.Get("", controller.Notification.GetNotifications)
+				notifications.Post("/:id/read", controller.Notification.MarkNotificationRead)
 			}
 
 			// ✅ PROTECTED Payment routes (butuh auth untuk action)
 			payments := protected.Group("/payments")
 			{
-				payments.Post("", controller.Payment.ProcessPayment)                                   // Butuh auth - Process payment
-				payments.Post("/:booking_id/transaction", controller.Payment.CreatePaymentTransaction) // Butuh auth - Create transaction
+				payments.Post("", controller.Payment.ProcessPayment)                                                            // Butuh auth - Process payment
+				payments.Post("/:booking_id/transaction", middlewares.Idempotency, controller.Payment.CreatePaymentTransaction) // Butuh auth - Create transaction
+				payments.Post("/:booking_id/refunds", middlewares.Idempotency, controller.Payment.RefundPayment)                // Admin only - Refund payment
+				payments.Post("/:booking_id/cash", middlewares.Idempotency, controller.Payment.RecordCashPayment)               // Admin only - Record cash payment
+				payments.Post("/shares/:id/transaction", middlewares.Idempotency, controller.SplitPayment.PayShare)
 			}
 		}
-
-		// Public payment notification (no auth required)
-		api.Post("/payments/notification", controller.Payment.HandlePaymentNotification)
 	}
 
 	// Health check endpoint
@@ -0,0 +1,137 @@
+// Package statemachine defines which status changes bookings and payments
+// may go through. The repositories apply a change only when the machine
+// allows it from the record's current status, so a late or replayed event
+// can never move a record backwards.
+package statemachine
+
+import (
+	"take-home-test/app/constants"
+	"take-home-test/pkg/customerror"
+)
+
+// Machine lists, per status, the statuses a record may move to. A status
+// without transitions is final.
+type Machine struct {
+	name        string
+	transitions map[string][]string
+}
+
+func New(name string, transitions map[string][]string) Machine {
+	return Machine{name: name, transitions: transitions}
+}
+
+// Name is the kind of record the machine applies to, e.g. "booking".
+func (m Machine) Name() string {
+	return m.name
+}
+
+// Can reports whether a record may move from one status to the other.
+func (m Machine) Can(from, to string) bool {
+	for _, next := range m.transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// From restricts the machine to the transitions out of status, for changes
+// that only apply to records in that status.
+func (m Machine) From(status string) Machine {
+	return New(m.name, map[string][]string{status: m.transitions[status]})
+}
+
+// Check returns a BadRequest error when the record may not move from one
+// status to the other.
+func (m Machine) Check(from, to string) error {
+	if m.Can(from, to) {
+		return nil
+	}
+	return customerror.NewBadRequestErrorf(constants.ErrInvalidStatusTransition, m.name, from, to)
+}
+
+// Booking: a booking is paid online or confirmed by an admin, ends as
+// completed or no_show, and can be canceled until then. A completed booking
+// can still be marked as a no-show, since completion only follows the clock.
+// A held booking is a slot offered to a waitlisted user, who confirms it into
+// a regular booking or lets it go.
+// A booking whose deposit is paid is partially_paid until the balance is
+// paid or an admin confirms it. Like a paid booking it is completed once it
+// ends; a balance never paid stays due on its payment, where an admin can
+// still record it in cash.
+var Booking = New("booking", map[string][]string{
+	constants.BOOKING_STATUS_HELD: {
+		constants.BOOKING_STATUS_PENDING,
+		constants.BOOKING_STATUS_PAID,
+		constants.BOOKING_STATUS_CANCELED,
+	},
+	constants.BOOKING_STATUS_PENDING: {
+		constants.BOOKING_STATUS_PARTIALLY_PAID,
+		constants.BOOKING_STATUS_PAID,
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_CANCELED,
+	},
+	constants.BOOKING_STATUS_PARTIALLY_PAID: {
+		constants.BOOKING_STATUS_PAID,
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_NO_SHOW,
+		constants.BOOKING_STATUS_CANCELED,
+	},
+	constants.BOOKING_STATUS_PAID: {
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_NO_SHOW,
+		constants.BOOKING_STATUS_CANCELED,
+	},
+	constants.BOOKING_STATUS_CONFIRMED: {
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_NO_SHOW,
+		constants.BOOKING_STATUS_CANCELED,
+	},
+	constants.BOOKING_STATUS_COMPLETED: {
+		constants.BOOKING_STATUS_NO_SHOW,
+	},
+})
+
+// Payment: a payment follows its attempts until it succeeds and never goes
+// back afterwards. An expired payment can still succeed when a leftover
+// transaction settles late. Money received can be refunded whatever the
+// payment's status. Staying in the same status is allowed so the amounts can
+// be updated on their own.
+var Payment = New("payment", map[string][]string{
+	constants.PAYMENT_STATUS_PENDING: {
+		constants.PAYMENT_STATUS_PENDING,
+		constants.PAYMENT_STATUS_SUCCESS,
+		constants.PAYMENT_STATUS_FAILED,
+		constants.PAYMENT_STATUS_EXPIRED,
+		constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+	constants.PAYMENT_STATUS_FAILED: {
+		constants.PAYMENT_STATUS_FAILED,
+		constants.PAYMENT_STATUS_PENDING,
+		constants.PAYMENT_STATUS_SUCCESS,
+		constants.PAYMENT_STATUS_EXPIRED,
+		constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+	constants.PAYMENT_STATUS_EXPIRED: {
+		constants.PAYMENT_STATUS_EXPIRED,
+		constants.PAYMENT_STATUS_SUCCESS,
+		constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+	constants.PAYMENT_STATUS_SUCCESS: {
+		constants.PAYMENT_STATUS_SUCCESS,
+		constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+	constants.PAYMENT_STATUS_PARTIALLY_REFUNDED: {
+		constants.PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+	constants.PAYMENT_STATUS_REFUNDED: {
+		constants.PAYMENT_STATUS_REFUNDED,
+	},
+})
@@ -2,12 +2,19 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
 	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -16,6 +23,10 @@ type authUsecase usecase
 type AuthInterface interface {
 	Register(ctx context.Context, req models.RegisterRequest) (*models.UserResponse, error)
 	Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenResponse, error)
+	Logout(ctx context.Context, userID, tokenID, sessionID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error)
+	CreateAdmin(ctx context.Context, req models.RegisterRequest) (*models.UserResponse, error)
 }
 
 func (u *authUsecase) Register(ctx context.Context, req models.RegisterRequest) (*models.UserResponse, error) {
@@ -39,7 +50,7 @@ func (u *authUsecase) Register(ctx context.Context, req models.RegisterRequest)
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: string(hashedPassword),
-		Role:     req.Role,
+		Role:     constants.ROLE_USER,
 	}
 
 	createdUser, err := u.Options.Repository.User.CreateUser(ctx, user)
@@ -59,21 +70,82 @@ func (u *authUsecase) Register(ctx context.Context, req models.RegisterRequest)
 	return userResponse, nil
 }
 
+// CreateAdmin bootstraps an admin account. An existing account with the same
+// email is promoted instead; its password is left untouched.
+func (u *authUsecase) CreateAdmin(ctx context.Context, req models.RegisterRequest) (*models.UserResponse, error) {
+	exists, err := u.Options.Repository.User.IsEmailExist(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if exists {
+		user, err = u.Options.Repository.User.FindByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.Options.Repository.User.UpdateUserRole(ctx, user.ID.String(), constants.ROLE_ADMIN); err != nil {
+			return nil, err
+		}
+		user.Role = constants.ROLE_ADMIN
+
+		if err := u.Options.Repository.Session.RevokeUserSessions(ctx, user.ID.String()); err != nil {
+			return nil, err
+		}
+	} else {
+		if len(req.Password) < constants.MIN_PASSWORD_LENGTH {
+			return nil, customerror.NewBadRequestError(constants.ErrInvalidPassword)
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err = u.Options.Repository.User.CreateUser(ctx, models.User{
+			Name:     req.Name,
+			Email:    req.Email,
+			Password: string(hashedPassword),
+			Role:     constants.ROLE_ADMIN,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}, nil
+}
+
 func (u *authUsecase) Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error) {
 	// Find user by email
 	user, err := u.Options.Repository.User.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, errors.New(constants.ErrInvalidCredentials)
+		return nil, customerror.NewUnauthorizedError(constants.ErrInvalidCredentials)
 	}
 
 	// Check password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return nil, errors.New(constants.ErrInvalidCredentials)
+		return nil, customerror.NewUnauthorizedError(constants.ErrInvalidCredentials)
+	}
+
+	// Start a new session with its first refresh token
+	session, err := u.Options.Repository.Session.CreateSession(ctx, models.UserSession{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(u.Options.Config.GetRefreshTokenTTL()),
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate JWT token
-	token, err := u.generateJWT(user)
+	tokens, err := u.issueTokens(ctx, user, session)
 	if err != nil {
 		return nil, err
 	}
@@ -88,21 +160,140 @@ func (u *authUsecase) Login(ctx context.Context, req models.LoginRequest) (*mode
 	}
 
 	loginResponse := &models.LoginResponse{
-		Token: token,
-		User:  userResponse,
+		Token:            tokens.Token,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresAt:        tokens.ExpiresAt,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+		User:             userResponse,
 	}
 
 	return loginResponse, nil
 }
 
-func (u *authUsecase) generateJWT(user models.User) (string, error) {
+// Refresh rotates a refresh token: the presented token is consumed and a new
+// access/refresh pair is issued for the same session. Presenting a token that
+// was already used revokes the whole session, since it means the token leaked.
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
+	token, err := u.Options.Repository.Session.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.Options.Repository.Session.GetSessionByID(ctx, token.SessionID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) || now.After(token.ExpiresAt) {
+		return nil, customerror.NewUnauthorizedError(constants.ErrInvalidRefresh)
+	}
+
+	consumed := false
+	if token.UsedAt == nil {
+		consumed, err = u.Options.Repository.Session.MarkRefreshTokenUsed(ctx, token.ID.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !consumed {
+		if err := u.Options.Repository.Session.RevokeSession(ctx, session.ID.String()); err != nil {
+			return nil, err
+		}
+		return nil, customerror.NewUnauthorizedError(constants.ErrInvalidRefresh)
+	}
+
+	// Reload the user so role changes are reflected in the new access token
+	user, err := u.Options.Repository.User.FindByID(ctx, token.UserID.String())
+	if err != nil {
+		return nil, customerror.NewUnauthorizedError(constants.ErrInvalidRefresh)
+	}
+
+	return u.issueTokens(ctx, user, session)
+}
+
+// Logout revokes the session behind the access token and denylists the token
+// itself until it would have expired anyway.
+func (u *authUsecase) Logout(ctx context.Context, userID, tokenID, sessionID string, expiresAt time.Time) error {
+	if err := u.Options.Repository.Session.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	return u.Options.Repository.Session.RevokeToken(ctx, models.RevokedToken{
+		JTI:       helpers.ParseUUID(tokenID),
+		UserID:    helpers.ParseUUID(userID),
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (u *authUsecase) IsTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error) {
+	revoked, err := u.Options.Repository.Session.IsTokenRevoked(ctx, tokenID)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	return u.Options.Repository.Session.IsSessionRevoked(ctx, sessionID)
+}
+
+func (u *authUsecase) issueTokens(ctx context.Context, user models.User, session models.UserSession) (*models.TokenResponse, error) {
+	accessToken, expiresAt, err := u.generateJWT(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.Options.Repository.Session.CreateRefreshToken(ctx, models.RefreshToken{
+		SessionID: session.ID,
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		Token:            accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        expiresAt,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (u *authUsecase) generateJWT(user models.User, sessionID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(u.Options.Config.GetAccessTokenTTL())
+
 	claims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
+		"sid":     sessionID.String(),
 		"user_id": user.ID.String(),
 		"email":   user.Email,
 		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // 24 hours
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(u.Options.Config.GetJWTSecret()))
-}
\ No newline at end of file
+	signed, err := token.SignedString([]byte(u.Options.Config.GetJWTSecret()))
+	return signed, expiresAt, err
+}
+
+// generateRefreshToken returns an opaque random token; only its hash is stored.
+func generateRefreshToken() (string, error) {
+	b := make([]byte, constants.REFRESH_TOKEN_BYTES)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,89 +3,350 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"take-home-test/app/constants"
 	"take-home-test/app/helpers"
 	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/app/statemachine"
+	"take-home-test/pkg/config"
+	"take-home-test/pkg/customerror"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type bookingUsecase usecase
 
+// expiryBatchSize bounds how many bookings a single expiry run handles.
+const expiryBatchSize = 100
+
 type BookingInterface interface {
 	CreateBooking(ctx context.Context, userID string, req models.CreateBookingRequest) (*models.BookingResponse, error)
+	CreateRecurringBooking(ctx context.Context, userID string, req models.RecurringBookingRequest) (*models.BookingSeriesResponse, []models.BookingConflict, error)
 	GetBookingByID(ctx context.Context, id string) (*models.BookingResponse, error)
-	GetUserBookings(ctx context.Context, userID string) ([]models.BookingResponse, error)
+	GetUserBookings(ctx context.Context, userID string, req models.BookingListRequest) ([]models.BookingResponse, *models.Pagination, error)
+	GetBookings(ctx context.Context, req models.AdminBookingListRequest) ([]models.BookingDetailResponse, *models.Pagination, error)
+	GetBookingDetails(ctx context.Context, id string) (*models.BookingDetailResponse, error)
+	CreateWalkInBooking(ctx context.Context, adminID string, req models.WalkInBookingRequest) (*models.BookingDetailResponse, error)
+	ConfirmBooking(ctx context.Context, adminID, id string) (*models.BookingResponse, error)
+	MarkNoShow(ctx context.Context, adminID, id string) (*models.BookingResponse, error)
+	CancelBooking(ctx context.Context, id string, actor models.Actor, req models.CancelBookingRequest) (*models.CancelBookingResponse, error)
+	RescheduleBooking(ctx context.Context, id string, actor models.Actor, req models.RescheduleBookingRequest) (*models.RescheduleBookingResponse, error)
+	ExpirePendingBookings(ctx context.Context) (int, error)
+	CompleteFinishedBookings(ctx context.Context) (int, error)
 }
 
 func (u *bookingUsecase) CreateBooking(ctx context.Context, userID string, req models.CreateBookingRequest) (*models.BookingResponse, error) {
+	holdExpiresAt := time.Now().Add(u.Options.Config.GetBookingHoldTTL())
+	createdBooking, err := u.create(ctx, models.Booking{
+		UserID:        helpers.ParseUUID(userID),
+		FieldID:       req.FieldID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Status:        constants.BOOKING_STATUS_PENDING,
+		HoldExpiresAt: &holdExpiresAt,
+	}, req.VoucherCode)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingResponse := toBookingResponse(createdBooking)
+
+	return &bookingResponse, nil
+}
+
+// create checks the slot, prices the booking and applies the voucher, then
+// stores the booking together with its payment.
+func (u *bookingUsecase) create(ctx context.Context, booking models.Booking, voucherCode string) (models.Booking, error) {
+	fieldID := booking.FieldID.String()
+
 	// Check if field exists
-	field, err := u.Options.Repository.Field.GetFieldByID(ctx, req.FieldID.String())
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
 	if err != nil {
-		return nil, fmt.Errorf(constants.ErrFieldNotFound, req.FieldID.String())
+		return booking, fmt.Errorf(constants.ErrFieldNotFound, fieldID)
 	}
 
-	hasOverlap, err := u.Options.Repository.Booking.CheckTimeOverlap(ctx, req.FieldID.String(), req.StartTime, req.EndTime)
+	hasOverlap, err := u.Options.Repository.Booking.CheckTimeOverlap(ctx, fieldID, booking.StartTime, booking.EndTime, "")
 	if err != nil {
-		return nil, err
+		return booking, err
 	}
 	if hasOverlap {
-		return nil, fmt.Errorf(constants.ErrTimeSlotOverlap)
+		return booking, customerror.NewConflictError(constants.ErrTimeSlotOverlap)
 	}
 
-	if !req.EndTime.After(req.StartTime) {
-		return nil, fmt.Errorf(constants.ErrInvalidTimeRange)
+	if !booking.EndTime.After(booking.StartTime) {
+		return booking, fmt.Errorf(constants.ErrInvalidTimeRange)
 	}
 
-	if req.StartTime.Before(time.Now()) {
-		return nil, fmt.Errorf(constants.ErrBookingInPast)
+	if booking.StartTime.Before(time.Now()) {
+		return booking, fmt.Errorf(constants.ErrBookingInPast)
 	}
 
 	minDuration := time.Hour
-	if req.EndTime.Sub(req.StartTime) < minDuration {
-		return nil, fmt.Errorf(constants.ErrMinimumDuration)
+	if booking.EndTime.Sub(booking.StartTime) < minDuration {
+		return booking, fmt.Errorf(constants.ErrMinimumDuration)
 	}
 
-	booking := models.Booking{
-		UserID:    helpers.ParseUUID(userID),
-		FieldID:   req.FieldID,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-		Status:    constants.BOOKING_STATUS_PENDING,
+	quote, err := (*pricingUsecase)(u).quote(ctx, field, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return booking, err
+	}
+	booking.Price = quote.Amount
+
+	payment := models.Payment{
+		Amount:         quote.Amount,
+		OriginalAmount: quote.Amount,
+		Status:         constants.PAYMENT_STATUS_PENDING,
+		PaymentMethod:  "",
+	}
+
+	var voucher *models.Voucher
+	if voucherCode != "" {
+		var discount int
+		voucher, discount, err = (*voucherUsecase)(u).applyVoucher(ctx, voucherCode, field.ID, quote.Amount)
+		if err != nil {
+			return booking, err
+		}
+		payment.VoucherID = &voucher.ID
+		payment.DiscountAmount = discount
+		payment.Amount = quote.Amount - discount
+	}
+	payment.DepositAmount = depositAmount(field, payment.Amount)
+
+	// Nothing is left to pay when a voucher covers the whole price, so the
+	// booking is settled right away instead of waiting for the gateway.
+	if payment.Amount == 0 {
+		now := time.Now()
+		booking.Status = constants.BOOKING_STATUS_PAID
+		booking.HoldExpiresAt = nil
+		payment.Status = constants.PAYMENT_STATUS_SUCCESS
+		payment.PaidAt = &now
 	}
 
-	createdBooking, err := u.Options.Repository.Booking.CreateBooking(ctx, booking)
+	// The overlap check above is only a fast path: concurrent requests are
+	// serialized by the bookings_no_overlap constraint, which turns the losing
+	// insert into a Conflict error and rolls back its payment as well.
+	var createdBooking models.Booking
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		createdBooking, err = tx.Booking.CreateBooking(ctx, booking)
+		if err != nil {
+			return err
+		}
+
+		if voucher != nil {
+			if err := redeemVoucher(ctx, tx, voucher, createdBooking, payment.DiscountAmount); err != nil {
+				return err
+			}
+		}
+
+		payment.BookingID = createdBooking.ID
+		_, err = tx.Payment.CreatePayment(ctx, payment)
+		return err
+	})
+	return createdBooking, err
+}
+
+// CreateRecurringBooking books the same slot weekly or every other week.
+// Every occurrence is checked against the field's closures and bookings
+// first; when any of them cannot be booked nothing is, and the conflicting
+// occurrences are returned with a Conflict error. Otherwise all bookings are
+// created in one transaction, held like a single booking and paid for with
+// one payment covering the whole series.
+func (u *bookingUsecase) CreateRecurringBooking(ctx context.Context, userID string, req models.RecurringBookingRequest) (*models.BookingSeriesResponse, []models.BookingConflict, error) {
+	loc := u.Options.Config.GetVenueLocation()
+	occurrences, err := recurrences(req, loc)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	first, last := occurrences[0], occurrences[len(occurrences)-1]
+	switch {
+	case !first.EndTime.After(first.StartTime):
+		return nil, nil, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	case first.StartTime.Before(time.Now()):
+		return nil, nil, customerror.NewBadRequestError(constants.ErrBookingInPast)
+	case first.EndTime.Sub(first.StartTime) < time.Hour:
+		return nil, nil, customerror.NewBadRequestError(constants.ErrMinimumDuration)
 	}
 
-	duration := req.EndTime.Sub(req.StartTime)
-	hours := int(duration.Hours())
-	if hours == 0 {
-		hours = 1
+	fieldID := req.FieldID.String()
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closures, err := loadFieldClosures(ctx, u.Options.Repository, loc, fieldID, first.StartTime, last.EndTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := u.Options.Repository.Booking.GetFieldBookingsInRange(ctx, fieldID, first.StartTime, last.EndTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var conflicts []models.BookingConflict
+	amount := 0
+	for i, occurrence := range occurrences {
+		overlaps := func(booking models.Booking) bool {
+			return booking.StartTime.Before(occurrence.EndTime) && booking.EndTime.After(occurrence.StartTime)
+		}
+
+		reason := ""
+		if err := closures.check(occurrence.StartTime, occurrence.EndTime); err != nil {
+			reason = err.Error()
+		} else if slices.ContainsFunc(existing, overlaps) {
+			reason = constants.ErrTimeSlotOverlap
+		}
+		if reason != "" {
+			conflicts = append(conflicts, models.BookingConflict{
+				StartTime: occurrence.StartTime,
+				EndTime:   occurrence.EndTime,
+				Reason:    reason,
+			})
+			continue
+		}
+
+		quote, err := (*pricingUsecase)(u).quote(ctx, field, occurrence.StartTime, occurrence.EndTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		occurrences[i].Price = quote.Amount
+		amount += quote.Amount
 	}
-	amount := hours * field.PricePerHour
 
+	if len(conflicts) > 0 {
+		return nil, conflicts, customerror.NewConflictErrorf(constants.ErrSeriesConflicts, len(conflicts), len(occurrences))
+	}
+
+	holdExpiresAt := time.Now().Add(u.Options.Config.GetBookingHoldTTL())
+	status := constants.BOOKING_STATUS_PENDING
 	payment := models.Payment{
-		BookingID:     createdBooking.ID,
-		Amount:        amount,
-		Status:        constants.PAYMENT_STATUS_PENDING,
-		PaymentMethod: "",
+		Amount:         amount,
+		OriginalAmount: amount,
+		DepositAmount:  depositAmount(field, amount),
+		Status:         constants.PAYMENT_STATUS_PENDING,
 	}
 
-	if _, err := u.Options.Repository.Payment.CreatePayment(ctx, payment); err != nil {
+	// Nothing to pay, e.g. every slot is free: settled right away
+	if amount == 0 {
+		now := time.Now()
+		status = constants.BOOKING_STATUS_PAID
+		payment.Status = constants.PAYMENT_STATUS_SUCCESS
+		payment.PaidAt = &now
 	}
 
-	bookingResponse := &models.BookingResponse{
-		ID:        createdBooking.ID,
-		UserID:    createdBooking.UserID,
-		FieldID:   createdBooking.FieldID,
-		StartTime: createdBooking.StartTime,
-		EndTime:   createdBooking.EndTime,
-		Status:    createdBooking.Status,
-		CreatedAt: createdBooking.CreatedAt,
+	var (
+		series   models.BookingSeries
+		bookings []models.Booking
+	)
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		series, err = tx.Booking.CreateSeries(ctx, models.BookingSeries{
+			UserID:      helpers.ParseUUID(userID),
+			FieldID:     field.ID,
+			Frequency:   req.Frequency,
+			Occurrences: len(occurrences),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, occurrence := range occurrences {
+			occurrence.UserID = series.UserID
+			occurrence.FieldID = series.FieldID
+			occurrence.Status = status
+			occurrence.SeriesID = &series.ID
+			if status == constants.BOOKING_STATUS_PENDING {
+				occurrence.HoldExpiresAt = &holdExpiresAt
+			}
+
+			// The bookings_no_overlap constraint still catches bookings made
+			// since the check above, rolling back the whole series.
+			booking, err := tx.Booking.CreateBooking(ctx, occurrence)
+			if err != nil {
+				return err
+			}
+			bookings = append(bookings, booking)
+		}
+
+		payment.BookingID = bookings[0].ID
+		payment.SeriesID = &series.ID
+		payment, err = tx.Payment.CreatePayment(ctx, payment)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return bookingResponse, nil
+	response := &models.BookingSeriesResponse{
+		ID:          series.ID,
+		FieldID:     series.FieldID,
+		Frequency:   series.Frequency,
+		Occurrences: series.Occurrences,
+		Amount:      payment.Amount,
+		PaymentID:   payment.ID,
+		CreatedAt:   series.CreatedAt,
+	}
+	for _, booking := range bookings {
+		response.Bookings = append(response.Bookings, toBookingResponse(booking))
+	}
+
+	return response, nil, nil
+}
+
+// recurrences expands the recurrence of req into its occurrences, which keep
+// the first booking's wall-clock time in the venue timezone.
+func recurrences(req models.RecurringBookingRequest, loc *time.Location) ([]models.Booking, error) {
+	days := 7
+	switch req.Frequency {
+	case constants.RECURRENCE_WEEKLY:
+	case constants.RECURRENCE_BIWEEKLY:
+		days = 14
+	default:
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidFrequency, req.Frequency)
+	}
+
+	if req.Count == 0 && req.Until == "" {
+		return nil, customerror.NewBadRequestError(constants.ErrRecurrenceEndRequired)
+	}
+	if req.Count < 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrTooFewOccurrences)
+	}
+
+	// until is exclusive: the day after the given date
+	var until time.Time
+	if req.Until != "" {
+		date, err := time.ParseInLocation(constants.DATE_FORMAT, req.Until, loc)
+		if err != nil {
+			return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidRecurrenceUntil, req.Until)
+		}
+		until = date.AddDate(0, 0, 1)
+	}
+
+	start, end := req.StartTime.In(loc), req.EndTime.In(loc)
+	var occurrences []models.Booking
+	for i := 0; req.Count == 0 || i < req.Count; i++ {
+		occurrence := models.Booking{
+			StartTime: start.AddDate(0, 0, i*days),
+			EndTime:   end.AddDate(0, 0, i*days),
+		}
+		if !until.IsZero() && !occurrence.StartTime.Before(until) {
+			break
+		}
+		if len(occurrences) == constants.MAX_RECURRING_OCCURRENCES {
+			return nil, customerror.NewBadRequestErrorf(constants.ErrTooManyOccurrences, constants.MAX_RECURRING_OCCURRENCES)
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	if len(occurrences) < 2 {
+		return nil, customerror.NewBadRequestError(constants.ErrTooFewOccurrences)
+	}
+
+	return occurrences, nil
 }
 
 func (u *bookingUsecase) GetBookingByID(ctx context.Context, id string) (*models.BookingResponse, error) {
@@ -94,37 +355,677 @@ func (u *bookingUsecase) GetBookingByID(ctx context.Context, id string) (*models
 		return nil, err
 	}
 
-	bookingResponse := &models.BookingResponse{
-		ID:        booking.ID,
-		UserID:    booking.UserID,
-		FieldID:   booking.FieldID,
-		StartTime: booking.StartTime,
-		EndTime:   booking.EndTime,
-		Status:    booking.Status,
-		CreatedAt: booking.CreatedAt,
+	bookingResponse := toBookingResponse(booking)
+
+	return &bookingResponse, nil
+}
+
+func (u *bookingUsecase) GetUserBookings(ctx context.Context, userID string, req models.BookingListRequest) ([]models.BookingResponse, *models.Pagination, error) {
+	filter, err := newBookingFilter(req, u.Options.Config.GetVenueLocation())
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.UserID = userID
+
+	bookings, pagination, err := u.Options.Repository.Booking.ListBookings(ctx, filter, req.PageRequest)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return bookingResponse, nil
+	var bookingResponses []models.BookingResponse
+	for _, booking := range bookings {
+		bookingResponses = append(bookingResponses, toBookingResponse(booking))
+	}
+
+	return bookingResponses, pagination, nil
 }
 
-func (u *bookingUsecase) GetUserBookings(ctx context.Context, userID string) ([]models.BookingResponse, error) {
-	bookings, err := u.Options.Repository.Booking.GetBookingsByUserID(ctx, userID)
+// GetBookings lists the bookings of every user with their customer, field and
+// payment.
+func (u *bookingUsecase) GetBookings(ctx context.Context, req models.AdminBookingListRequest) ([]models.BookingDetailResponse, *models.Pagination, error) {
+	filter, err := newBookingFilter(req.BookingListRequest, u.Options.Config.GetVenueLocation())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.UserID != "" {
+		if !helpers.IsValidUUID(req.UserID) {
+			return nil, nil, customerror.NewBadRequestErrorf(constants.ErrInvalidUUIDParam, "user_id")
+		}
+		filter.UserID = req.UserID
+	}
+	filter.Search = strings.TrimSpace(req.Search)
+
+	bookings, pagination, err := u.Options.Repository.Booking.ListBookings(ctx, filter, req.PageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	details, err := u.bookingDetails(ctx, bookings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return details, pagination, nil
+}
+
+// GetBookingDetails shows a booking with its customer, field and payment,
+// including the payment's attempts and refunds.
+func (u *bookingUsecase) GetBookingDetails(ctx context.Context, id string) (*models.BookingDetailResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	var bookingResponses []models.BookingResponse
+	details, err := u.bookingDetails(ctx, []models.Booking{booking})
+	if err != nil {
+		return nil, err
+	}
+	detail := details[0]
+
+	payment, err := (*paymentUsecase)(u).GetPaymentByBookingID(ctx, id)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); !ok {
+			return nil, err
+		}
+	}
+	detail.Payment = payment
+
+	entityIDs := []string{id}
+	if payment != nil {
+		entityIDs = append(entityIDs, payment.ID.String())
+	}
+	history, err := u.Options.Repository.StatusHistory.GetStatusHistory(ctx, entityIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range history {
+		detail.History = append(detail.History, toStatusHistoryResponse(change))
+	}
+
+	return &detail, nil
+}
+
+// bookingDetails loads the users, fields and payments of the bookings with
+// one query each.
+func (u *bookingUsecase) bookingDetails(ctx context.Context, bookings []models.Booking) ([]models.BookingDetailResponse, error) {
+	details := make([]models.BookingDetailResponse, 0, len(bookings))
+	if len(bookings) == 0 {
+		return details, nil
+	}
+
+	var bookingIDs, userIDs, fieldIDs []string
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.ID.String())
+		userIDs = append(userIDs, booking.UserID.String())
+		fieldIDs = append(fieldIDs, booking.FieldID.String())
+	}
+
+	users, err := u.Options.Repository.User.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	usersByID := make(map[uuid.UUID]*models.UserResponse, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = &models.UserResponse{
+			ID:        user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+		}
+	}
+
+	fields, err := u.Options.Repository.Field.GetFieldsByIDs(ctx, fieldIDs)
+	if err != nil {
+		return nil, err
+	}
+	fieldsByID := make(map[uuid.UUID]*models.FieldResponse, len(fields))
+	for _, field := range fields {
+		fieldsByID[field.ID] = &models.FieldResponse{
+			ID:             field.ID,
+			Name:           field.Name,
+			PricePerHour:   field.PricePerHour,
+			DepositPercent: field.DepositPercent,
+			Location:       field.Location,
+			CreatedAt:      field.CreatedAt,
+			UpdatedAt:      field.UpdatedAt,
+		}
+	}
+
+	payments, err := u.Options.Repository.Payment.GetPaymentsByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		return nil, err
+	}
+	paymentsByBookingID := make(map[uuid.UUID]*models.PaymentResponse, len(payments))
+	paymentsBySeriesID := make(map[uuid.UUID]*models.PaymentResponse)
+	paymentsByOrderID := make(map[uuid.UUID]*models.PaymentResponse)
+	for _, payment := range payments {
+		response := toPaymentResponse(payment)
+		paymentsByBookingID[payment.BookingID] = response
+		if payment.SeriesID != nil {
+			paymentsBySeriesID[*payment.SeriesID] = response
+		}
+		if payment.OrderID != nil {
+			paymentsByOrderID[*payment.OrderID] = response
+		}
+	}
+
 	for _, booking := range bookings {
-		bookingResponses = append(bookingResponses, models.BookingResponse{
-			ID:        booking.ID,
+		payment := paymentsByBookingID[booking.ID]
+		if booking.SeriesID != nil {
+			payment = paymentsBySeriesID[*booking.SeriesID]
+		}
+		if booking.OrderID != nil {
+			payment = paymentsByOrderID[*booking.OrderID]
+		}
+
+		details = append(details, models.BookingDetailResponse{
+			BookingResponse: toBookingResponse(booking),
+			User:            usersByID[booking.UserID],
+			Field:           fieldsByID[booking.FieldID],
+			Payment:         payment,
+		})
+	}
+
+	return details, nil
+}
+
+// CreateWalkInBooking books a slot for a customer at the counter. The booking
+// is confirmed right away instead of being held for an online payment; with
+// a payment method it is also recorded as paid in full.
+func (u *bookingUsecase) CreateWalkInBooking(ctx context.Context, adminID string, req models.WalkInBookingRequest) (*models.BookingDetailResponse, error) {
+	createdBy := helpers.ParseUUID(adminID)
+	booking := models.Booking{
+		UserID:        createdBy,
+		FieldID:       req.FieldID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Status:        constants.BOOKING_STATUS_CONFIRMED,
+		Channel:       constants.BOOKING_CHANNEL_WALK_IN,
+		CustomerName:  strings.TrimSpace(req.CustomerName),
+		CustomerPhone: strings.TrimSpace(req.CustomerPhone),
+		CreatedBy:     &createdBy,
+	}
+
+	if req.UserID != nil {
+		user, err := u.Options.Repository.User.FindByID(ctx, req.UserID.String())
+		if err != nil {
+			return nil, err
+		}
+		booking.UserID = user.ID
+	} else if booking.CustomerName == "" {
+		return nil, customerror.NewBadRequestError(constants.ErrWalkInCustomer)
+	}
+
+	createdBooking, err := u.create(ctx, booking, req.VoucherCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PaymentMethod != "" && createdBooking.Status != constants.BOOKING_STATUS_PAID {
+		_, err := (*paymentUsecase)(u).ProcessPayment(ctx, createdBooking.ID.String(), adminActor(adminID), models.CreatePaymentRequest{
+			BookingID:     createdBooking.ID,
+			PaymentMethod: req.PaymentMethod,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.GetBookingDetails(ctx, createdBooking.ID.String())
+}
+
+// ConfirmBooking confirms a pending or paid booking by hand, e.g. one that
+// will be paid at the venue. A confirmed booking is not expired when its
+// hold runs out.
+func (u *bookingUsecase) ConfirmBooking(ctx context.Context, adminID, id string) (*models.BookingResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Options.Repository.Booking.TransitionBooking(ctx, id, models.StatusChange{
+		To:    constants.BOOKING_STATUS_CONFIRMED,
+		Actor: adminActor(adminID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	booking.Status = constants.BOOKING_STATUS_CONFIRMED
+	booking.HoldExpiresAt = nil
+	bookingResponse := toBookingResponse(booking)
+
+	return &bookingResponse, nil
+}
+
+// MarkNoShow records that the customer of a booking that has started did not
+// turn up. The slot stays taken and nothing is refunded.
+func (u *bookingUsecase) MarkNoShow(ctx context.Context, adminID, id string) (*models.BookingResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := statemachine.Booking.Check(booking.Status, constants.BOOKING_STATUS_NO_SHOW); err != nil {
+		return nil, err
+	}
+
+	if booking.StartTime.After(time.Now()) {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingNotStarted)
+	}
+
+	err = u.Options.Repository.Booking.TransitionBooking(ctx, id, models.StatusChange{
+		To:    constants.BOOKING_STATUS_NO_SHOW,
+		Actor: adminActor(adminID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	booking.Status = constants.BOOKING_STATUS_NO_SHOW
+	bookingResponse := toBookingResponse(booking)
+
+	return &bookingResponse, nil
+}
+
+// newBookingFilter validates the filters of a booking list request. A date
+// used as "to" includes the whole day.
+func newBookingFilter(req models.BookingListRequest, loc *time.Location) (models.BookingFilter, error) {
+	var filter models.BookingFilter
+
+	if req.FieldID != "" {
+		if !helpers.IsValidUUID(req.FieldID) {
+			return filter, customerror.NewBadRequestErrorf(constants.ErrInvalidUUIDParam, "field_id")
+		}
+		filter.FieldID = req.FieldID
+	}
+
+	for _, status := range strings.Split(req.Status, ",") {
+		status = strings.TrimSpace(status)
+		if status == "" {
+			continue
+		}
+		if !helpers.Contains(constants.ValidBookingStatuses, status) {
+			return filter, customerror.NewBadRequestErrorf(constants.ErrInvalidBookingStatus, status)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if req.From != "" {
+		from, _, err := parseCalendarTime(req.From, loc)
+		if err != nil {
+			return filter, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "from")
+		}
+		filter.From = &from
+	}
+
+	if req.To != "" {
+		to, dateOnly, err := parseCalendarTime(req.To, loc)
+		if err != nil {
+			return filter, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "to")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return filter, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	}
+
+	return filter, nil
+}
+
+// CancelBooking cancels a booking that has not started yet and, when it was
+// paid, refunds the share allowed by the cancellation policy.
+func (u *bookingUsecase) CancelBooking(ctx context.Context, id string, actor models.Actor, req models.CancelBookingRequest) (*models.CancelBookingResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status == constants.BOOKING_STATUS_CANCELED {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingCanceled)
+	}
+
+	untilStart := time.Until(booking.StartTime)
+	if untilStart <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingStarted)
+	}
+
+	// Whatever was paid towards a booking that never got fully paid, e.g.
+	// the shares of a split payment, goes back in full
+	percent := refundPercent(u.Options.Config, untilStart)
+	if booking.Status == constants.BOOKING_STATUS_PENDING {
+		percent = 100
+	}
+
+	return u.cancel(ctx, booking, percent, actor, req.Reason)
+}
+
+// cancel cancels the booking and refunds percent of what was paid for it,
+// capped by what has not been refunded yet.
+func (u *bookingUsecase) cancel(ctx context.Context, booking models.Booking, percent int, actor models.Actor, reason string) (*models.CancelBookingResponse, error) {
+	id := booking.ID.String()
+	err := u.Options.Repository.Booking.TransitionBooking(ctx, id, models.StatusChange{
+		To:     constants.BOOKING_STATUS_CANCELED,
+		Actor:  actor,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	(*waitlistUsecase)(u).releaseSlot(ctx, booking, constants.WAITLIST_STATUS_CANCELED)
+
+	if err := u.Options.Repository.Voucher.ReleaseRedemption(ctx, id); err != nil {
+		return nil, err
+	}
+
+	booking.Status = constants.BOOKING_STATUS_CANCELED
+	response := &models.CancelBookingResponse{
+		Booking:       toBookingResponse(booking),
+		RefundPercent: percent,
+	}
+
+	payment, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, id)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); ok {
+			return response, nil
+		}
+		return nil, err
+	}
+
+	if payment.SplitDeadline != nil {
+		if err := u.Options.Repository.Share.CloseShares(ctx, payment.ID.String(), constants.SHARE_STATUS_CANCELED); err != nil {
+			return nil, err
+		}
+	}
+
+	refundable := payment.PaidAmount - payment.RefundedAmount
+	amount := min(bookingShare(payment, booking)*response.RefundPercent/100, refundable)
+	if amount <= 0 {
+		return response, nil
+	}
+
+	if reason == "" {
+		reason = "Booking canceled"
+	}
+
+	if _, err := (*paymentUsecase)(u).refund(ctx, payment, booking.ID, amount, reason, constants.REFUND_SOURCE_CANCELLATION, nil); err != nil {
+		return nil, customerror.NewInternalServiceErrorf(constants.ErrRefundFailed, err)
+	}
+	response.RefundAmount = amount
+
+	return response, nil
+}
+
+// RescheduleBooking moves a booking that has not started yet to another
+// slot of the same field, checked like a new booking apart from overlapping
+// the booking itself. The price is quoted again and the payment follows the
+// difference: an unpaid booking simply costs the new amount, a paid booking
+// that became more expensive gets a transaction for the rest, and what was
+// paid beyond the new amount is kept as credit. The slot given up is offered
+// to the waitlist.
+func (u *bookingUsecase) RescheduleBooking(ctx context.Context, id string, actor models.Actor, req models.RescheduleBookingRequest) (*models.RescheduleBookingResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch booking.Status {
+	case constants.BOOKING_STATUS_PENDING, constants.BOOKING_STATUS_PARTIALLY_PAID, constants.BOOKING_STATUS_PAID, constants.BOOKING_STATUS_CONFIRMED:
+	default:
+		return nil, customerror.NewBadRequestError(constants.ErrBookingNotReschedulable)
+	}
+	if !booking.StartTime.After(time.Now()) {
+		return nil, customerror.NewBadRequestError(constants.ErrRescheduleStarted)
+	}
+	if booking.StartTime.Equal(req.StartTime) && booking.EndTime.Equal(req.EndTime) {
+		return nil, customerror.NewBadRequestError(constants.ErrSameSlot)
+	}
+
+	fieldID := booking.FieldID.String()
+	if err := (*validateUsecase)(u).isValidSlot(ctx, fieldID, req.StartTime, req.EndTime, id); err != nil {
+		return nil, err
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := (*pricingUsecase)(u).quote(ctx, field, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.RescheduleBookingResponse{
+		PriceDifference: quote.Amount - booking.Price,
+	}
+
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, id)
+	hasPayment := err == nil
+	if _, ok := err.(customerror.NotFoundError); err != nil && !ok {
+		return nil, err
+	}
+	// The shares were worked out for the current price
+	if hasPayment && paymentRecord.SplitDeadline != nil && booking.Status == constants.BOOKING_STATUS_PENDING {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentSplit)
+	}
+
+	// The discount and deposit stay as they were. Money paid beyond the new
+	// amount is credited; credit kept from an earlier move of the booking
+	// pays for an increase first and is taken back accordingly.
+	oldAmount, newAmount, credit := 0, 0, 0
+	if hasPayment {
+		oldAmount = paymentRecord.Amount
+		paymentRecord.OriginalAmount += response.PriceDifference
+		newAmount = max(paymentRecord.OriginalAmount-paymentRecord.DiscountAmount, 0)
+		paid := paymentRecord.PaidAmount
+		credit = max(paid-newAmount, 0) - max(paid-oldAmount, 0)
+		response.AmountDue = max(newAmount-paid, 0)
+		response.Credit = credit
+	}
+
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		if err := tx.Booking.RescheduleBooking(ctx, id, req.StartTime, req.EndTime, quote.Amount); err != nil {
+			return err
+		}
+		if !hasPayment {
+			return nil
+		}
+
+		if err := tx.Payment.UpdateAmounts(ctx, paymentRecord.ID.String(), paymentRecord.OriginalAmount, newAmount); err != nil {
+			return err
+		}
+
+		if credit == 0 {
+			return nil
+		}
+		reason := "Booking rescheduled to a cheaper slot"
+		if credit < 0 {
+			reason = "Credit used for a rescheduled booking"
+		}
+		_, err := tx.Credit.CreateCredit(ctx, models.Credit{
 			UserID:    booking.UserID,
-			FieldID:   booking.FieldID,
-			StartTime: booking.StartTime,
-			EndTime:   booking.EndTime,
-			Status:    booking.Status,
-			CreatedAt: booking.CreatedAt,
+			BookingID: &booking.ID,
+			PaymentID: &paymentRecord.ID,
+			Amount:    credit,
+			Reason:    reason,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The old slot is free now
+	if err := (*waitlistUsecase)(u).offerSlot(ctx, booking); err != nil {
+		log.Printf("offering slot of booking %s to the waitlist: %v", booking.ID, err)
+	}
+
+	if hasPayment && newAmount != oldAmount {
+		// Pending transactions were opened for the old amount
+		if err := (*paymentUsecase)(u).expireAttempts(ctx, paymentRecord); err != nil {
+			return nil, err
+		}
+
+		// What was paid may cover a cheaper slot entirely
+		if newAmount < oldAmount {
+			if err := (*paymentUsecase)(u).settlePayment(ctx, paymentRecord.ID.String(), actor); err != nil {
+				return nil, err
+			}
+		}
+
+		// A booking paid for already is charged the difference right away;
+		// an unpaid one is paid for in full as usual, and one secured with a
+		// deposit still has its balance paid later.
+		if response.AmountDue > 0 && paymentRecord.PaidAmount > 0 && booking.Status != constants.BOOKING_STATUS_PARTIALLY_PAID {
+			response.Transaction, err = (*paymentUsecase)(u).CreatePaymentTransaction(ctx, id)
+			if err != nil {
+				log.Printf("charging rescheduled booking %s: %v", id, err)
+			}
+		}
+	}
+
+	booking, err = u.Options.Repository.Booking.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response.Booking = toBookingResponse(booking)
+
+	return response, nil
+}
+
+// refundPercent applies the cancellation policy to the time left before the
+// booking starts: a full refund up to REFUND_FULL_BEFORE, the partial share
+// up to REFUND_CUTOFF and nothing afterwards.
+func refundPercent(cfg *config.Config, untilStart time.Duration) int {
+	switch {
+	case untilStart >= cfg.GetRefundFullBefore():
+		return 100
+	case untilStart >= cfg.GetRefundCutoff():
+		return cfg.GetRefundPartialPercent()
+	default:
+		return 0
+	}
+}
+
+// ExpirePendingBookings cancels pending bookings and waitlist offers whose
+// hold window has passed, releasing their slots to the waitlist, and closes
+// their unpaid payment. It is run periodically by the scheduler and returns
+// how many bookings expired.
+func (u *bookingUsecase) ExpirePendingBookings(ctx context.Context) (int, error) {
+	bookings, err := u.Options.Repository.Booking.GetExpiredPendingBookings(ctx, time.Now(), expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, booking := range bookings {
+		reason := "Payment hold expired"
+		if booking.Status == constants.BOOKING_STATUS_HELD {
+			reason = "Waitlist offer expired"
+		}
+
+		err := u.Options.Repository.Booking.TransitionBooking(ctx, booking.ID.String(), models.StatusChange{
+			To:     constants.BOOKING_STATUS_CANCELED,
+			Actor:  models.Actor{Source: constants.STATUS_SOURCE_SYSTEM},
+			Reason: reason,
 		})
+		if err != nil {
+			if _, ok := err.(customerror.BadRequestError); ok {
+				// Paid or canceled since it was listed
+				continue
+			}
+			return expired, err
+		}
+		expired++
+		(*waitlistUsecase)(u).releaseSlot(ctx, booking, constants.WAITLIST_STATUS_EXPIRED)
+
+		if err := u.Options.Repository.Voucher.ReleaseRedemption(ctx, booking.ID.String()); err != nil {
+			return expired, err
+		}
+
+		if err := (*paymentUsecase)(u).expire(ctx, booking.ID.String()); err != nil {
+			return expired, err
+		}
 	}
 
-	return bookingResponses, nil
+	return expired, nil
+}
+
+// CompleteFinishedBookings marks partially paid, paid and confirmed bookings
+// that have ended as completed. It is run periodically by the scheduler and
+// returns how many bookings were completed.
+func (u *bookingUsecase) CompleteFinishedBookings(ctx context.Context) (int, error) {
+	bookings, err := u.Options.Repository.Booking.GetFinishedBookings(ctx, time.Now(), expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, booking := range bookings {
+		err := u.Options.Repository.Booking.TransitionBooking(ctx, booking.ID.String(), models.StatusChange{
+			To:    constants.BOOKING_STATUS_COMPLETED,
+			Actor: models.Actor{Source: constants.STATUS_SOURCE_SYSTEM},
+		})
+		if err != nil {
+			if _, ok := err.(customerror.BadRequestError); ok {
+				// Canceled or marked as no-show since it was listed
+				continue
+			}
+			return completed, err
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// adminActor is the actor of a change made through an admin-only endpoint.
+func adminActor(adminID string) models.Actor {
+	id := helpers.ParseUUID(adminID)
+	return models.Actor{Source: constants.STATUS_SOURCE_ADMIN, ID: &id}
+}
+
+func toBookingResponse(booking models.Booking) models.BookingResponse {
+	response := models.BookingResponse{
+		ID:            booking.ID,
+		UserID:        booking.UserID,
+		FieldID:       booking.FieldID,
+		StartTime:     booking.StartTime,
+		EndTime:       booking.EndTime,
+		Status:        booking.Status,
+		Channel:       booking.Channel,
+		CustomerName:  booking.CustomerName,
+		CustomerPhone: booking.CustomerPhone,
+		SeriesID:      booking.SeriesID,
+		OrderID:       booking.OrderID,
+		Price:         booking.Price,
+		CreatedAt:     booking.CreatedAt,
+	}
+
+	// The hold only matters while the booking waits for its payment, or for
+	// the waitlisted user to confirm it
+	if booking.Status == constants.BOOKING_STATUS_PENDING || booking.Status == constants.BOOKING_STATUS_HELD {
+		response.HoldExpiresAt = booking.HoldExpiresAt
+	}
+
+	return response
+}
+
+func toStatusHistoryResponse(change models.StatusHistory) models.StatusHistoryResponse {
+	return models.StatusHistoryResponse{
+		EntityType: change.EntityType,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		Source:     change.Source,
+		ActorID:    change.ActorID,
+		Reason:     change.Reason,
+		CreatedAt:  change.CreatedAt,
+	}
 }
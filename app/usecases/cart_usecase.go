@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type cartUsecase usecase
+
+type CartInterface interface {
+	AddCartItem(ctx context.Context, userID string, req models.CartItemRequest) (*models.CartResponse, error)
+	GetCart(ctx context.Context, userID string) (*models.CartResponse, error)
+	RemoveCartItem(ctx context.Context, userID, bookingID string) (*models.CartResponse, error)
+	Checkout(ctx context.Context, userID string) (*models.CheckoutResponse, error)
+}
+
+// AddCartItem books the slot as a pending booking of the user's open cart,
+// opening a cart first when there is none. Every slot of a cart is held
+// until the cart expires, BOOKING_HOLD_TTL after it was opened.
+func (u *cartUsecase) AddCartItem(ctx context.Context, userID string, req models.CartItemRequest) (*models.CartResponse, error) {
+	fieldID := req.FieldID.String()
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := (*validateUsecase)(u).isValidSlot(ctx, fieldID, req.StartTime, req.EndTime, ""); err != nil {
+		return nil, err
+	}
+
+	order, err := u.openOrder(ctx, userID)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); !ok {
+			return nil, err
+		}
+
+		order, err = u.Options.Repository.Order.CreateOrder(ctx, models.Order{
+			UserID:    helpers.ParseUUID(userID),
+			Status:    constants.ORDER_STATUS_OPEN,
+			ExpiresAt: time.Now().Add(u.Options.Config.GetBookingHoldTTL()),
+		})
+		if err != nil {
+			if _, ok := err.(customerror.ConflictError); !ok {
+				return nil, err
+			}
+			// Opened by a concurrent request
+			if order, err = u.openOrder(ctx, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	bookings, err := u.cartItems(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) >= constants.MAX_CART_ITEMS {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrCartFull, constants.MAX_CART_ITEMS)
+	}
+
+	quote, err := (*pricingUsecase)(u).quote(ctx, field, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The bookings_no_overlap constraint still catches a slot booked since
+	// the check above
+	_, err = u.Options.Repository.Booking.CreateBooking(ctx, models.Booking{
+		UserID:        order.UserID,
+		FieldID:       field.ID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Status:        constants.BOOKING_STATUS_PENDING,
+		HoldExpiresAt: &order.ExpiresAt,
+		OrderID:       &order.ID,
+		Price:         quote.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.cart(ctx, order, nil)
+}
+
+func (u *cartUsecase) GetCart(ctx context.Context, userID string) (*models.CartResponse, error) {
+	order, err := u.openOrder(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.cart(ctx, order, nil)
+}
+
+// RemoveCartItem cancels a booking of the user's open cart, freeing its slot.
+func (u *cartUsecase) RemoveCartItem(ctx context.Context, userID, bookingID string) (*models.CartResponse, error) {
+	order, err := u.openOrder(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.OrderID == nil || *booking.OrderID != order.ID || booking.Status == constants.BOOKING_STATUS_CANCELED {
+		return nil, customerror.NewNotFoundErrorf(constants.ErrCartItemNotFound, bookingID)
+	}
+
+	_, err = (*bookingUsecase)(u).cancel(ctx, booking, 0, models.Actor{
+		Source: constants.STATUS_SOURCE_USER,
+		ID:     &order.UserID,
+	}, "Removed from cart")
+	if err != nil {
+		return nil, err
+	}
+
+	return u.cart(ctx, order, nil)
+}
+
+// Checkout closes the user's open cart and creates a single payment for all
+// of its bookings, held for another BOOKING_HOLD_TTL while it is paid. The
+// transaction paying for it lists every booking as its own item; when it
+// cannot be created the cart is still checked out and its payment can be
+// retried through any of its bookings.
+func (u *cartUsecase) Checkout(ctx context.Context, userID string) (*models.CheckoutResponse, error) {
+	order, err := u.openOrder(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := u.cartItems(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrCartEmpty)
+	}
+
+	// Every booking asks for its deposit up front, or its full price on a
+	// field taking none; the cart is secured once all of that is paid
+	fields := make(map[uuid.UUID]models.Field)
+	amount, upFront := 0, 0
+	for _, booking := range bookings {
+		field, ok := fields[booking.FieldID]
+		if !ok {
+			field, err = u.Options.Repository.Field.GetFieldByID(ctx, booking.FieldID.String())
+			if err != nil {
+				return nil, err
+			}
+			fields[booking.FieldID] = field
+		}
+		amount += booking.Price
+		upFront += upFrontAmount(field, booking.Price)
+	}
+
+	payment := models.Payment{
+		BookingID:      bookings[0].ID,
+		OrderID:        &order.ID,
+		Amount:         amount,
+		OriginalAmount: amount,
+		Status:         constants.PAYMENT_STATUS_PENDING,
+	}
+	if upFront < amount {
+		payment.DepositAmount = upFront
+	}
+
+	// Nothing to pay, e.g. every slot is free: settled right away
+	if amount == 0 {
+		now := time.Now()
+		payment.Status = constants.PAYMENT_STATUS_SUCCESS
+		payment.PaidAt = &now
+	}
+
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		closed, err := tx.Order.UpdateOrderStatus(ctx, order.ID.String(), constants.ORDER_STATUS_OPEN, constants.ORDER_STATUS_CHECKED_OUT)
+		if err != nil {
+			return err
+		}
+		if !closed {
+			// Checked out by a concurrent request
+			return customerror.NewNotFoundError(constants.ErrCartNotFound)
+		}
+
+		if payment, err = tx.Payment.CreatePayment(ctx, payment); err != nil {
+			return err
+		}
+
+		if amount > 0 {
+			return tx.Booking.ExtendOrderHold(ctx, order.ID.String(), time.Now().Add(u.Options.Config.GetBookingHoldTTL()))
+		}
+
+		for _, booking := range bookings {
+			err := tx.Booking.TransitionBooking(ctx, booking.ID.String(), models.StatusChange{
+				To:    constants.BOOKING_STATUS_PAID,
+				Actor: models.Actor{Source: constants.STATUS_SOURCE_USER, ID: &order.UserID},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	order.Status = constants.ORDER_STATUS_CHECKED_OUT
+
+	response := &models.CheckoutResponse{}
+	if amount > 0 {
+		response.Transaction, err = (*paymentUsecase)(u).CreatePaymentTransaction(ctx, bookings[0].ID.String())
+		if err != nil {
+			log.Printf("creating transaction for order %s: %v", order.ID, err)
+		}
+	}
+
+	cart, err := u.cart(ctx, order, &payment)
+	if err != nil {
+		return nil, err
+	}
+	response.Cart = *cart
+
+	return response, nil
+}
+
+// openOrder returns the user's open cart. A cart whose hold has passed is
+// closed as expired instead, its bookings being expired with their holds.
+func (u *cartUsecase) openOrder(ctx context.Context, userID string) (models.Order, error) {
+	order, err := u.Options.Repository.Order.GetOpenOrder(ctx, userID)
+	if err != nil {
+		return order, err
+	}
+
+	if order.ExpiresAt.After(time.Now()) {
+		return order, nil
+	}
+
+	if _, err := u.Options.Repository.Order.UpdateOrderStatus(ctx, order.ID.String(), constants.ORDER_STATUS_OPEN, constants.ORDER_STATUS_EXPIRED); err != nil {
+		return order, err
+	}
+	return order, customerror.NewNotFoundError(constants.ErrCartNotFound)
+}
+
+// cartItems returns the bookings of the order that were not canceled.
+func (u *cartUsecase) cartItems(ctx context.Context, order models.Order) ([]models.Booking, error) {
+	bookings, err := u.Options.Repository.Booking.GetOrderBookings(ctx, order.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.Status != constants.BOOKING_STATUS_CANCELED {
+			items = append(items, booking)
+		}
+	}
+	return items, nil
+}
+
+func (u *cartUsecase) cart(ctx context.Context, order models.Order, payment *models.Payment) (*models.CartResponse, error) {
+	bookings, err := u.cartItems(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.CartResponse{
+		ID:        order.ID,
+		Status:    order.Status,
+		ExpiresAt: order.ExpiresAt,
+		Items:     make([]models.BookingResponse, 0, len(bookings)),
+		CreatedAt: order.CreatedAt,
+	}
+	for _, booking := range bookings {
+		response.Items = append(response.Items, toBookingResponse(booking))
+		response.Total += booking.Price
+	}
+	if payment != nil {
+		response.PaymentID = &payment.ID
+	}
+
+	return response, nil
+}
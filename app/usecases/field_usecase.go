@@ -2,24 +2,51 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"take-home-test/app/constants"
 	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type fieldUsecase usecase
 
+// Bounds of the availability calendar
+const (
+	minAvailabilitySlot = 15 * time.Minute
+	maxAvailabilitySlot = 24 * time.Hour
+	maxAvailabilityDays = 31
+)
+
 type FieldInterface interface {
 	CreateField(ctx context.Context, req models.CreateFieldRequest) (*models.FieldResponse, error)
-	GetFields(ctx context.Context) ([]models.FieldResponse, error)
+	GetFields(ctx context.Context, req models.FieldListRequest) ([]models.FieldResponse, *models.Pagination, error)
 	GetFieldByID(ctx context.Context, id string) (*models.FieldResponse, error)
 	UpdateField(ctx context.Context, id string, req models.UpdateFieldRequest) (*models.FieldResponse, error)
 	DeleteField(ctx context.Context, id string) error
+	GetFieldAvailability(ctx context.Context, id string, req models.FieldAvailabilityRequest) (*models.FieldAvailabilityResponse, error)
+	GetFieldSchedule(ctx context.Context, id string) ([]models.FieldScheduleResponse, error)
+	ReplaceFieldSchedule(ctx context.Context, id string, req models.ReplaceFieldScheduleRequest) ([]models.FieldScheduleResponse, error)
+	AddFieldSchedule(ctx context.Context, id string, req models.FieldScheduleRequest) (*models.FieldScheduleResponse, error)
+	DeleteFieldSchedule(ctx context.Context, id, scheduleID string) error
+	GetFieldBlackouts(ctx context.Context, id string) ([]models.FieldBlackoutResponse, error)
+	CreateFieldBlackout(ctx context.Context, adminID, id string, req models.CreateFieldBlackoutRequest) (*models.CreateFieldBlackoutResponse, error)
+	GetBlackoutConflicts(ctx context.Context, id, blackoutID string) (*models.BlackoutConflictsResponse, error)
+	CancelBlackoutConflicts(ctx context.Context, adminID, id, blackoutID string) (*models.BlackoutConflictsResponse, error)
+	DeleteFieldBlackout(ctx context.Context, id, blackoutID string) error
 }
 
 func (u *fieldUsecase) CreateField(ctx context.Context, req models.CreateFieldRequest) (*models.FieldResponse, error) {
 	field := models.Field{
-		Name:         req.Name,
-		PricePerHour: req.PricePerHour,
-		Location:     req.Location,
+		Name:           req.Name,
+		PricePerHour:   req.PricePerHour,
+		DepositPercent: req.DepositPercent,
+		Location:       req.Location,
 	}
 
 	createdField, err := u.Options.Repository.Field.CreateField(ctx, field)
@@ -28,36 +55,42 @@ func (u *fieldUsecase) CreateField(ctx context.Context, req models.CreateFieldRe
 	}
 
 	fieldResponse := &models.FieldResponse{
-		ID:           createdField.ID,
-		Name:         createdField.Name,
-		PricePerHour: createdField.PricePerHour,
-		Location:     createdField.Location,
-		CreatedAt:    createdField.CreatedAt,
-		UpdatedAt:    createdField.UpdatedAt,
+		ID:             createdField.ID,
+		Name:           createdField.Name,
+		PricePerHour:   createdField.PricePerHour,
+		DepositPercent: createdField.DepositPercent,
+		Location:       createdField.Location,
+		CreatedAt:      createdField.CreatedAt,
+		UpdatedAt:      createdField.UpdatedAt,
 	}
 
 	return fieldResponse, nil
 }
 
-func (u *fieldUsecase) GetFields(ctx context.Context) ([]models.FieldResponse, error) {
-	fields, err := u.Options.Repository.Field.GetFields(ctx)
+func (u *fieldUsecase) GetFields(ctx context.Context, req models.FieldListRequest) ([]models.FieldResponse, *models.Pagination, error) {
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return nil, nil, customerror.NewBadRequestError(constants.ErrInvalidPriceRange)
+	}
+
+	fields, pagination, err := u.Options.Repository.Field.ListFields(ctx, req.FieldFilter, req.PageRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var fieldResponses []models.FieldResponse
 	for _, field := range fields {
 		fieldResponses = append(fieldResponses, models.FieldResponse{
-			ID:           field.ID,
-			Name:         field.Name,
-			PricePerHour: field.PricePerHour,
-			Location:     field.Location,
-			CreatedAt:    field.CreatedAt,
-			UpdatedAt:    field.UpdatedAt,
+			ID:             field.ID,
+			Name:           field.Name,
+			PricePerHour:   field.PricePerHour,
+			DepositPercent: field.DepositPercent,
+			Location:       field.Location,
+			CreatedAt:      field.CreatedAt,
+			UpdatedAt:      field.UpdatedAt,
 		})
 	}
 
-	return fieldResponses, nil
+	return fieldResponses, pagination, nil
 }
 
 func (u *fieldUsecase) GetFieldByID(ctx context.Context, id string) (*models.FieldResponse, error) {
@@ -67,12 +100,13 @@ func (u *fieldUsecase) GetFieldByID(ctx context.Context, id string) (*models.Fie
 	}
 
 	fieldResponse := &models.FieldResponse{
-		ID:           field.ID,
-		Name:         field.Name,
-		PricePerHour: field.PricePerHour,
-		Location:     field.Location,
-		CreatedAt:    field.CreatedAt,
-		UpdatedAt:    field.UpdatedAt,
+		ID:             field.ID,
+		Name:           field.Name,
+		PricePerHour:   field.PricePerHour,
+		DepositPercent: field.DepositPercent,
+		Location:       field.Location,
+		CreatedAt:      field.CreatedAt,
+		UpdatedAt:      field.UpdatedAt,
 	}
 
 	return fieldResponse, nil
@@ -88,6 +122,7 @@ func (u *fieldUsecase) UpdateField(ctx context.Context, id string, req models.Up
 	// Update field data
 	existingField.Name = req.Name
 	existingField.PricePerHour = req.PricePerHour
+	existingField.DepositPercent = req.DepositPercent
 	existingField.Location = req.Location
 
 	updatedField, err := u.Options.Repository.Field.UpdateField(ctx, existingField)
@@ -96,12 +131,13 @@ func (u *fieldUsecase) UpdateField(ctx context.Context, id string, req models.Up
 	}
 
 	fieldResponse := &models.FieldResponse{
-		ID:           updatedField.ID,
-		Name:         updatedField.Name,
-		PricePerHour: updatedField.PricePerHour,
-		Location:     updatedField.Location,
-		CreatedAt:    updatedField.CreatedAt,
-		UpdatedAt:    updatedField.UpdatedAt,
+		ID:             updatedField.ID,
+		Name:           updatedField.Name,
+		PricePerHour:   updatedField.PricePerHour,
+		DepositPercent: updatedField.DepositPercent,
+		Location:       updatedField.Location,
+		CreatedAt:      updatedField.CreatedAt,
+		UpdatedAt:      updatedField.UpdatedAt,
 	}
 
 	return fieldResponse, nil
@@ -110,3 +146,504 @@ func (u *fieldUsecase) UpdateField(ctx context.Context, id string, req models.Up
 func (u *fieldUsecase) DeleteField(ctx context.Context, id string) error {
 	return u.Options.Repository.Field.DeleteField(ctx, id)
 }
+
+// GetFieldAvailability splits [from, to) into slots of the requested length
+// and reports each one as free, taken by a booking, closed (outside opening
+// hours, during a blackout or on a holiday) or already in the past.
+// Without from/to it covers the current day in the venue timezone.
+func (u *fieldUsecase) GetFieldAvailability(ctx context.Context, id string, req models.FieldAvailabilityRequest) (*models.FieldAvailabilityResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := u.Options.Config.GetVenueLocation()
+
+	slot := time.Hour
+	if req.Slot != "" {
+		slot, err = time.ParseDuration(req.Slot)
+		if err != nil || slot < minAvailabilitySlot || slot > maxAvailabilitySlot {
+			return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidSlot, minAvailabilitySlot, maxAvailabilitySlot)
+		}
+	}
+
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if req.From != "" {
+		if from, _, err = parseCalendarTime(req.From, loc); err != nil {
+			return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "from")
+		}
+	}
+
+	to := from.AddDate(0, 0, 1)
+	if req.To != "" {
+		var dateOnly bool
+		if to, dateOnly, err = parseCalendarTime(req.To, loc); err != nil {
+			return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "to")
+		}
+		// A plain date includes the whole day
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	if !to.After(from) {
+		return nil, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	}
+	if to.Sub(from) > maxAvailabilityDays*24*time.Hour {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrRangeTooLong, maxAvailabilityDays)
+	}
+
+	bookings, err := u.Options.Repository.Booking.GetFieldBookingsInRange(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := loadFieldClosures(ctx, u.Options.Repository, loc, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.FieldAvailabilityResponse{
+		FieldID: field.ID,
+		From:    from.In(loc),
+		To:      to.In(loc),
+		Slot:    slot.String(),
+		Slots:   []models.AvailabilitySlot{},
+	}
+
+	// Bookings are sorted by start time and slots only move forward, so
+	// bookings that ended before the current slot can be dropped for good.
+	next := 0
+	for start := from; !start.Add(slot).After(to); start = start.Add(slot) {
+		end := start.Add(slot)
+		for next < len(bookings) && !bookings[next].EndTime.After(start) {
+			next++
+		}
+
+		status := constants.SLOT_STATUS_FREE
+		for _, booking := range bookings[next:] {
+			if !booking.StartTime.Before(end) {
+				break
+			}
+			if booking.EndTime.After(start) {
+				status = constants.SLOT_STATUS_TAKEN
+				break
+			}
+		}
+		if status == constants.SLOT_STATUS_FREE && closures.check(start, end) != nil {
+			status = constants.SLOT_STATUS_CLOSED
+		}
+		if status == constants.SLOT_STATUS_FREE && start.Before(now) {
+			status = constants.SLOT_STATUS_PAST
+		}
+
+		response.Slots = append(response.Slots, models.AvailabilitySlot{
+			StartTime: start.In(loc),
+			EndTime:   end.In(loc),
+			Status:    status,
+		})
+	}
+
+	return response, nil
+}
+
+// parseCalendarTime accepts the booking time formats or a plain YYYY-MM-DD
+// date, which is read as midnight in the venue timezone.
+func parseCalendarTime(value string, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	if t, err = parseTime(value); err == nil {
+		return t, false, nil
+	}
+
+	t, err = time.ParseInLocation(constants.DATE_FORMAT, value, loc)
+	return t, true, err
+}
+
+func (u *fieldUsecase) GetFieldSchedule(ctx context.Context, id string) ([]models.FieldScheduleResponse, error) {
+	if _, err := u.Options.Repository.Field.GetFieldByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	schedules, err := u.Options.Repository.FieldSchedule.GetSchedulesByFieldID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFieldScheduleResponses(schedules), nil
+}
+
+// ReplaceFieldSchedule sets the complete weekly opening hours of a field. An
+// empty list removes them, leaving the field bookable around the clock.
+func (u *fieldUsecase) ReplaceFieldSchedule(ctx context.Context, id string, req models.ReplaceFieldScheduleRequest) ([]models.FieldScheduleResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]models.FieldSchedule, 0, len(req.Ranges))
+	for _, r := range req.Ranges {
+		schedule, err := newFieldSchedule(field.ID, r)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := checkScheduleOverlaps(schedules); err != nil {
+		return nil, err
+	}
+
+	schedules, err = u.Options.Repository.FieldSchedule.ReplaceSchedules(ctx, id, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFieldScheduleResponses(schedules), nil
+}
+
+func (u *fieldUsecase) AddFieldSchedule(ctx context.Context, id string, req models.FieldScheduleRequest) (*models.FieldScheduleResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := newFieldSchedule(field.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.Options.Repository.FieldSchedule.GetSchedulesByFieldID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScheduleOverlaps(append(existing, schedule)); err != nil {
+		return nil, err
+	}
+
+	schedule, err = u.Options.Repository.FieldSchedule.CreateSchedule(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFieldScheduleResponses([]models.FieldSchedule{schedule})[0]
+	return &response, nil
+}
+
+func (u *fieldUsecase) DeleteFieldSchedule(ctx context.Context, id, scheduleID string) error {
+	return u.Options.Repository.FieldSchedule.DeleteSchedule(ctx, id, scheduleID)
+}
+
+func newFieldSchedule(fieldID uuid.UUID, req models.FieldScheduleRequest) (models.FieldSchedule, error) {
+	day := strings.ToLower(strings.TrimSpace(req.DayOfWeek))
+	if !slices.Contains(constants.ArrayDays, day) {
+		return models.FieldSchedule{}, customerror.NewBadRequestErrorf(constants.ErrInvalidDayOfWeek, req.DayOfWeek)
+	}
+
+	opens, opensOK := clockMinutes(req.OpenTime)
+	closes, closesOK := clockMinutes(req.CloseTime)
+	if !opensOK || !closesOK || opens == minutesPerDay || closes <= opens {
+		return models.FieldSchedule{}, customerror.NewBadRequestErrorf(constants.ErrInvalidOpeningTime, req.OpenTime, req.CloseTime)
+	}
+
+	return models.FieldSchedule{
+		FieldID:   fieldID,
+		DayOfWeek: day,
+		OpenTime:  formatClock(opens),
+		CloseTime: formatClock(closes),
+	}, nil
+}
+
+// checkScheduleOverlaps rejects ranges of the same day that overlap; ranges
+// that only touch (10:00-12:00 and 12:00-14:00) are fine.
+func checkScheduleOverlaps(schedules []models.FieldSchedule) error {
+	for i, a := range schedules {
+		for _, b := range schedules[i+1:] {
+			if a.DayOfWeek != b.DayOfWeek {
+				continue
+			}
+			if a.OpenTime < b.CloseTime && b.OpenTime < a.CloseTime {
+				return customerror.NewBadRequestErrorf(constants.ErrScheduleOverlap, a.DayOfWeek, a.OpenTime, a.CloseTime, b.OpenTime, b.CloseTime)
+			}
+		}
+	}
+	return nil
+}
+
+func toFieldScheduleResponses(schedules []models.FieldSchedule) []models.FieldScheduleResponse {
+	sorted := slices.Clone(schedules)
+	slices.SortFunc(sorted, func(a, b models.FieldSchedule) int {
+		if c := slices.Index(constants.ArrayDays, a.DayOfWeek) - slices.Index(constants.ArrayDays, b.DayOfWeek); c != 0 {
+			return c
+		}
+		return strings.Compare(a.OpenTime, b.OpenTime)
+	})
+
+	responses := make([]models.FieldScheduleResponse, 0, len(sorted))
+	for _, schedule := range sorted {
+		responses = append(responses, models.FieldScheduleResponse{
+			ID:        schedule.ID,
+			DayOfWeek: schedule.DayOfWeek,
+			OpenTime:  schedule.OpenTime,
+			CloseTime: schedule.CloseTime,
+		})
+	}
+	return responses
+}
+
+const minutesPerDay = 24 * 60
+
+// clockMinutes parses "HH:MM" (up to "24:00") into minutes after midnight.
+func clockMinutes(value string) (int, bool) {
+	if value == "24:00" {
+		return minutesPerDay, true
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil || len(value) != 5 {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// withinOpeningHours reports whether [start, end) lies entirely inside the
+// field's opening hours, following ranges across midnight when one closes at
+// 24:00 and the next day opens at 00:00. A field without opening hours is
+// always open.
+func withinOpeningHours(schedules []models.FieldSchedule, start, end time.Time, loc *time.Location) bool {
+	if len(schedules) == 0 {
+		return true
+	}
+
+	cursor, end := start.In(loc), end.In(loc)
+	for cursor.Before(end) {
+		midnight := time.Date(cursor.Year(), cursor.Month(), cursor.Day(), 0, 0, 0, 0, loc)
+		minute := cursor.Hour()*60 + cursor.Minute()
+		day := strings.ToLower(cursor.Weekday().String())
+
+		next := cursor
+		for _, schedule := range schedules {
+			opens, _ := clockMinutes(schedule.OpenTime)
+			closes, _ := clockMinutes(schedule.CloseTime)
+			if schedule.DayOfWeek == day && opens <= minute && minute < closes {
+				next = midnight.Add(time.Duration(closes) * time.Minute)
+				break
+			}
+		}
+
+		if !next.After(cursor) {
+			return false
+		}
+		cursor = next
+	}
+
+	return true
+}
+
+// fieldClosures gathers what closes a field apart from its bookings: the
+// weekly opening hours, maintenance blackouts and venue holidays.
+type fieldClosures struct {
+	schedules []models.FieldSchedule
+	blackouts []models.FieldBlackout
+	holidays  map[string]models.Holiday // by YYYY-MM-DD
+	loc       *time.Location
+}
+
+// loadFieldClosures reads the closures of a field relevant to [from, to).
+func loadFieldClosures(ctx context.Context, repo *repositories.Main, loc *time.Location, fieldID string, from, to time.Time) (*fieldClosures, error) {
+	schedules, err := repo.FieldSchedule.GetSchedulesByFieldID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := repo.FieldBlackout.GetBlackoutsInRange(ctx, fieldID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	firstDay, lastDay := holidayDate(from, loc), holidayDate(to.Add(-time.Nanosecond), loc)
+	holidays, err := repo.Holiday.GetHolidays(ctx, &firstDay, &lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	closures := &fieldClosures{
+		schedules: schedules,
+		blackouts: blackouts,
+		holidays:  make(map[string]models.Holiday, len(holidays)),
+		loc:       loc,
+	}
+	for _, holiday := range holidays {
+		closures.holidays[holiday.Date.UTC().Format(constants.DATE_FORMAT)] = holiday
+	}
+
+	return closures, nil
+}
+
+// check returns why [start, end) cannot be booked, or nil when the field is
+// open for the whole range.
+func (c *fieldClosures) check(start, end time.Time) error {
+	if !withinOpeningHours(c.schedules, start, end, c.loc) {
+		return customerror.NewBadRequestError(constants.ErrOutsideOpenHours)
+	}
+
+	for _, blackout := range c.blackouts {
+		if blackout.StartTime.Before(end) && blackout.EndTime.After(start) {
+			return customerror.NewBadRequestErrorf(constants.ErrFieldBlackedOut,
+				blackout.StartTime.In(c.loc).Format(time.RFC3339), blackout.EndTime.In(c.loc).Format(time.RFC3339))
+		}
+	}
+
+	last := holidayDate(end.Add(-time.Nanosecond), c.loc)
+	for day := holidayDate(start, c.loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(constants.DATE_FORMAT)
+		if holiday, ok := c.holidays[date]; ok {
+			return customerror.NewBadRequestErrorf(constants.ErrHolidayClosed, date, holiday.Name)
+		}
+	}
+
+	return nil
+}
+
+// holidayDate returns the venue-local date of t in the form holidays are
+// stored: midnight UTC of that date.
+func holidayDate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (u *fieldUsecase) GetFieldBlackouts(ctx context.Context, id string) ([]models.FieldBlackoutResponse, error) {
+	if _, err := u.Options.Repository.Field.GetFieldByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	blackouts, err := u.Options.Repository.FieldBlackout.GetBlackoutsByFieldID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.FieldBlackoutResponse, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		responses = append(responses, toFieldBlackoutResponse(blackout))
+	}
+	return responses, nil
+}
+
+// CreateFieldBlackout closes a field for maintenance. Bookings already made
+// for that window are not touched unless CancelConflicts is set, in which
+// case they are canceled with a full refund; either way they are listed in
+// the response.
+func (u *fieldUsecase) CreateFieldBlackout(ctx context.Context, adminID, id string, req models.CreateFieldBlackoutRequest) (*models.CreateFieldBlackoutResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.EndTime.After(req.StartTime) {
+		return nil, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	}
+
+	blackout, err := u.Options.Repository.FieldBlackout.CreateBlackout(ctx, models.FieldBlackout{
+		FieldID:   field.ID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Reason:    strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := u.blackoutConflicts(ctx, blackout, req.CancelConflicts, adminActor(adminID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateFieldBlackoutResponse{
+		Blackout:  toFieldBlackoutResponse(blackout),
+		Conflicts: conflicts.Conflicts,
+		Canceled:  conflicts.Canceled,
+	}, nil
+}
+
+// GetBlackoutConflicts lists the bookings that have not ended yet and collide
+// with the blackout.
+func (u *fieldUsecase) GetBlackoutConflicts(ctx context.Context, id, blackoutID string) (*models.BlackoutConflictsResponse, error) {
+	blackout, err := u.Options.Repository.FieldBlackout.GetBlackoutByID(ctx, id, blackoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.blackoutConflicts(ctx, blackout, false, models.Actor{})
+}
+
+// CancelBlackoutConflicts cancels, with a full refund, every booking that has
+// not ended yet and collides with the blackout.
+func (u *fieldUsecase) CancelBlackoutConflicts(ctx context.Context, adminID, id, blackoutID string) (*models.BlackoutConflictsResponse, error) {
+	blackout, err := u.Options.Repository.FieldBlackout.GetBlackoutByID(ctx, id, blackoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.blackoutConflicts(ctx, blackout, true, adminActor(adminID))
+}
+
+func (u *fieldUsecase) DeleteFieldBlackout(ctx context.Context, id, blackoutID string) error {
+	return u.Options.Repository.FieldBlackout.DeleteBlackout(ctx, id, blackoutID)
+}
+
+// blackoutConflicts collects the bookings colliding with the blackout and,
+// when cancel is set, cancels them on behalf of actor. The venue closed the
+// field, so customers get everything they paid back whatever the
+// cancellation policy says.
+func (u *fieldUsecase) blackoutConflicts(ctx context.Context, blackout models.FieldBlackout, cancel bool, actor models.Actor) (*models.BlackoutConflictsResponse, error) {
+	bookings, err := u.Options.Repository.Booking.GetFieldBookingsInRange(ctx, blackout.FieldID.String(), blackout.StartTime, blackout.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := "Field closed for maintenance"
+	if blackout.Reason != "" {
+		reason += ": " + blackout.Reason
+	}
+
+	response := &models.BlackoutConflictsResponse{Conflicts: []models.BookingResponse{}}
+	now := time.Now()
+	for _, booking := range bookings {
+		if !booking.EndTime.After(now) {
+			continue
+		}
+
+		if !cancel {
+			response.Conflicts = append(response.Conflicts, toBookingResponse(booking))
+			continue
+		}
+
+		canceled, err := (*bookingUsecase)(u).cancel(ctx, booking, 100, actor, reason)
+		if err != nil {
+			if _, ok := err.(customerror.BadRequestError); ok {
+				// Canceled by someone else since it was listed
+				continue
+			}
+			return nil, err
+		}
+		response.Conflicts = append(response.Conflicts, canceled.Booking)
+		response.Canceled++
+	}
+
+	return response, nil
+}
+
+func toFieldBlackoutResponse(blackout models.FieldBlackout) models.FieldBlackoutResponse {
+	return models.FieldBlackoutResponse{
+		ID:        blackout.ID,
+		FieldID:   blackout.FieldID,
+		StartTime: blackout.StartTime,
+		EndTime:   blackout.EndTime,
+		Reason:    blackout.Reason,
+		CreatedAt: blackout.CreatedAt,
+	}
+}
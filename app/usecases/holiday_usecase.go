@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"take-home-test/pkg/ical"
+	"time"
+)
+
+type holidayUsecase usecase
+
+// maxImportedHolidays bounds how many dates a single calendar import may add.
+const maxImportedHolidays = 1000
+
+type HolidayInterface interface {
+	GetHolidays(ctx context.Context, req models.HolidayListRequest) ([]models.HolidayResponse, error)
+	CreateHoliday(ctx context.Context, req models.HolidayRequest) (*models.HolidayResponse, error)
+	ImportHolidays(ctx context.Context, calendar io.Reader) (*models.ImportHolidaysResponse, error)
+	DeleteHoliday(ctx context.Context, id string) error
+}
+
+func (u *holidayUsecase) GetHolidays(ctx context.Context, req models.HolidayListRequest) ([]models.HolidayResponse, error) {
+	var from, to *time.Time
+	if req.From != "" {
+		date, err := parseHolidayDate(req.From)
+		if err != nil {
+			return nil, err
+		}
+		from = &date
+	}
+	if req.To != "" {
+		date, err := parseHolidayDate(req.To)
+		if err != nil {
+			return nil, err
+		}
+		to = &date
+	}
+
+	holidays, err := u.Options.Repository.Holiday.GetHolidays(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHolidayResponses(holidays), nil
+}
+
+// CreateHoliday closes the venue on a date. A date that already is a holiday
+// is renamed.
+func (u *holidayUsecase) CreateHoliday(ctx context.Context, req models.HolidayRequest) (*models.HolidayResponse, error) {
+	date, err := parseHolidayDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, customerror.NewBadRequestError(constants.ErrHolidayNameRequired)
+	}
+
+	holidays, err := u.Options.Repository.Holiday.UpsertHolidays(ctx, []models.Holiday{{Date: date, Name: name}})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toHolidayResponses(holidays)[0]
+	return &response, nil
+}
+
+// ImportHolidays adds every date covered by the events of an iCalendar file
+// as a holiday named after the event. All-day events keep their dates;
+// timed events close the venue-local dates they touch. Dates that already
+// are holidays are renamed, so a calendar can be imported again after it
+// changed.
+func (u *holidayUsecase) ImportHolidays(ctx context.Context, calendar io.Reader) (*models.ImportHolidaysResponse, error) {
+	events, err := ical.Parse(calendar)
+	if err != nil {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidCalendar, err)
+	}
+
+	loc := u.Options.Config.GetVenueLocation()
+
+	var holidays []models.Holiday
+	seen := make(map[string]bool)
+	for _, event := range events {
+		if !event.AllDay {
+			event.Start, event.End = event.Start.In(loc), event.End.In(loc)
+		}
+
+		name := strings.TrimSpace(event.Summary)
+		if name == "" {
+			name = "Holiday"
+		}
+
+		for _, day := range event.Days() {
+			if seen[day] {
+				continue
+			}
+			seen[day] = true
+
+			if len(holidays) == maxImportedHolidays {
+				return nil, customerror.NewBadRequestErrorf(constants.ErrTooManyHolidays, maxImportedHolidays)
+			}
+
+			date, _ := time.Parse(constants.DATE_FORMAT, day)
+			holidays = append(holidays, models.Holiday{Date: date, Name: name})
+		}
+	}
+
+	holidays, err = u.Options.Repository.Holiday.UpsertHolidays(ctx, holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ImportHolidaysResponse{
+		Imported: len(holidays),
+		Holidays: toHolidayResponses(holidays),
+	}, nil
+}
+
+func (u *holidayUsecase) DeleteHoliday(ctx context.Context, id string) error {
+	return u.Options.Repository.Holiday.DeleteHoliday(ctx, id)
+}
+
+// parseHolidayDate reads a YYYY-MM-DD date in the form holidays are stored.
+func parseHolidayDate(value string) (time.Time, error) {
+	date, err := time.Parse(constants.DATE_FORMAT, value)
+	if err != nil {
+		return date, customerror.NewBadRequestErrorf(constants.ErrInvalidHolidayDate, value)
+	}
+	return date, nil
+}
+
+func toHolidayResponses(holidays []models.Holiday) []models.HolidayResponse {
+	responses := make([]models.HolidayResponse, 0, len(holidays))
+	for _, holiday := range holidays {
+		responses = append(responses, models.HolidayResponse{
+			ID:   holiday.ID,
+			Date: holiday.Date.UTC().Format(constants.DATE_FORMAT),
+			Name: holiday.Name,
+		})
+	}
+	return responses
+}
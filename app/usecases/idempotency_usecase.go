@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"take-home-test/pkg/middleware"
+	"time"
+)
+
+type idempotencyUsecase usecase
+
+// idempotencyLockTimeout is how long a key may stay processing before a
+// retry takes it over, e.g. after the server stopped mid-request.
+const idempotencyLockTimeout = time.Minute
+
+type IdempotencyInterface interface {
+	middleware.IdempotencyStore
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
+}
+
+func (u *idempotencyUsecase) BeginIdempotentRequest(ctx context.Context, userID, key, requestHash string) (*middleware.IdempotentResponse, error) {
+	now := time.Now()
+	reserved, err := u.Options.Repository.Idempotency.ReserveKey(ctx, models.IdempotencyKey{
+		UserID:      helpers.ParseUUID(userID),
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(u.Options.Config.GetIdempotencyKeyTTL()),
+	}, now.Add(-idempotencyLockTimeout))
+	if err != nil || reserved {
+		return nil, err
+	}
+
+	record, err := u.Options.Repository.Idempotency.GetKey(ctx, userID, key)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); ok {
+			// Released by its request in the meantime; the client retries
+			return nil, customerror.NewConflictErrorf(constants.ErrIdempotencyKeyInProgress, key)
+		}
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, customerror.NewConflictErrorf(constants.ErrIdempotencyKeyReused, key)
+	}
+
+	if record.Status != constants.IDEMPOTENCY_STATUS_COMPLETED {
+		return nil, customerror.NewConflictErrorf(constants.ErrIdempotencyKeyInProgress, key)
+	}
+
+	return &middleware.IdempotentResponse{
+		StatusCode:  record.ResponseStatus,
+		ContentType: record.ResponseContentType,
+		Body:        record.ResponseBody,
+	}, nil
+}
+
+func (u *idempotencyUsecase) CompleteIdempotentRequest(ctx context.Context, userID, key string, response middleware.IdempotentResponse) error {
+	return u.Options.Repository.Idempotency.CompleteKey(ctx, userID, key, response.StatusCode, response.ContentType, response.Body)
+}
+
+func (u *idempotencyUsecase) ReleaseIdempotentRequest(ctx context.Context, userID, key string) error {
+	return u.Options.Repository.Idempotency.ReleaseKey(ctx, userID, key)
+}
+
+// PurgeExpiredKeys deletes keys past their IDEMPOTENCY_KEY_TTL. It is run
+// periodically by the scheduler and returns how many keys were deleted.
+func (u *idempotencyUsecase) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	return u.Options.Repository.Idempotency.DeleteExpiredKeys(ctx, time.Now())
+}
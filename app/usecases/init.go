@@ -3,15 +3,24 @@ package usecase
 import (
 	"take-home-test/app/repositories"
 	"take-home-test/pkg/config"
+	"take-home-test/pkg/payment"
 )
 
 type Main struct {
-	User     UserInterface
-	Field    FieldInterface
-	Booking  BookingInterface
-	Payment  PaymentInterface
-	Auth     AuthInterface
-	Validate ValidateInterface
+	User         UserInterface
+	Field        FieldInterface
+	Booking      BookingInterface
+	Payment      PaymentInterface
+	Auth         AuthInterface
+	Validate     ValidateInterface
+	Holiday      HolidayInterface
+	Pricing      PricingInterface
+	Voucher      VoucherInterface
+	Idempotency  IdempotencyInterface
+	Waitlist     WaitlistInterface
+	Notification NotificationInterface
+	Cart         CartInterface
+	SplitPayment SplitPaymentInterface
 }
 
 type usecase struct {
@@ -19,20 +28,29 @@ type usecase struct {
 }
 
 type Options struct {
-	Repository *repositories.Main
-	Config     *config.Config
+	Repository     *repositories.Main
+	Config         *config.Config
+	PaymentGateway payment.PaymentGateway
 }
 
 func Init(opts Options) *Main {
 	uc := &usecase{opts}
 
 	m := &Main{
-		User:     (*userUsecase)(uc),
-		Field:    (*fieldUsecase)(uc),
-		Booking:  (*bookingUsecase)(uc),
-		Payment:  (*paymentUsecase)(uc),
-		Auth:     (*authUsecase)(uc),
-		Validate: (*validateUsecase)(uc),
+		User:         (*userUsecase)(uc),
+		Field:        (*fieldUsecase)(uc),
+		Booking:      (*bookingUsecase)(uc),
+		Payment:      (*paymentUsecase)(uc),
+		Auth:         (*authUsecase)(uc),
+		Validate:     (*validateUsecase)(uc),
+		Holiday:      (*holidayUsecase)(uc),
+		Pricing:      (*pricingUsecase)(uc),
+		Voucher:      (*voucherUsecase)(uc),
+		Idempotency:  (*idempotencyUsecase)(uc),
+		Waitlist:     (*waitlistUsecase)(uc),
+		Notification: (*notificationUsecase)(uc),
+		Cart:         (*cartUsecase)(uc),
+		SplitPayment: (*splitPaymentUsecase)(uc),
 	}
 
 	return m
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"take-home-test/app/models"
+	"time"
+)
+
+type notificationUsecase usecase
+
+type NotificationInterface interface {
+	GetNotifications(ctx context.Context, userID string, req models.NotificationListRequest) ([]models.NotificationResponse, *models.Pagination, error)
+	MarkNotificationRead(ctx context.Context, userID, id string) error
+}
+
+func (u *notificationUsecase) GetNotifications(ctx context.Context, userID string, req models.NotificationListRequest) ([]models.NotificationResponse, *models.Pagination, error) {
+	notifications, pagination, err := u.Options.Repository.Notification.GetUserNotifications(ctx, userID, req.Unread, req.PageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]models.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		responses = append(responses, models.NotificationResponse{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			Title:     notification.Title,
+			Message:   notification.Message,
+			BookingID: notification.BookingID,
+			ReadAt:    notification.ReadAt,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	return responses, pagination, nil
+}
+
+func (u *notificationUsecase) MarkNotificationRead(ctx context.Context, userID, id string) error {
+	return u.Options.Repository.Notification.MarkRead(ctx, userID, id, time.Now())
+}
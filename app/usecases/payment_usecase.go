@@ -2,23 +2,43 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
 	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
 	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/app/statemachine"
+	"take-home-test/pkg/customerror"
 	"take-home-test/pkg/payment"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/midtrans/midtrans-go/coreapi"
 )
 
 type paymentUsecase usecase
 
 type PaymentInterface interface {
-	ProcessPayment(ctx context.Context, bookingID string, req models.CreatePaymentRequest) (*models.PaymentResponse, error)
+	ProcessPayment(ctx context.Context, bookingID string, actor models.Actor, req models.CreatePaymentRequest) (*models.PaymentResponse, error)
 	GetPaymentByBookingID(ctx context.Context, bookingID string) (*models.PaymentResponse, error)
 	CreatePaymentTransaction(ctx context.Context, bookingID string) (*models.PaymentTransactionResponse, error)
 	HandlePaymentNotification(ctx context.Context, payload map[string]interface{}) error
+	GetFakeTransaction(ctx context.Context, orderID string) (*coreapi.TransactionStatusResponse, error)
+	SimulatePayment(ctx context.Context, orderID string, req models.SimulatePaymentRequest) error
+	RefundPayment(ctx context.Context, bookingID, adminID string, req models.RefundRequest) (*models.PaymentResponse, error)
+	RecordCashPayment(ctx context.Context, bookingID, adminID string, req models.CashPaymentRequest) (*models.PaymentResponse, error)
 }
 
+// CreatePaymentTransaction starts a new gateway attempt for the booking's
+// payment. Every attempt gets its own order ID so retries never collide at the
+// gateway, while the booking keeps a single payment record.
 func (u *paymentUsecase) CreatePaymentTransaction(ctx context.Context, bookingID string) (*models.PaymentTransactionResponse, error) {
 	fmt.Printf("🔧 Creating REAL payment transaction for booking: %s\n", bookingID)
 
@@ -37,58 +57,108 @@ func (u *paymentUsecase) CreatePaymentTransaction(ctx context.Context, bookingID
 		return nil, err
 	}
 
-	existingPayment, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
-	if err == nil && existingPayment.ID != uuid.Nil {
-		if existingPayment.Status == constants.PAYMENT_STATUS_SUCCESS {
-			return nil, fmt.Errorf(constants.ErrPaymentAlreadyProcessed)
+	if booking.Status == constants.BOOKING_STATUS_CANCELED {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingCanceled)
+	}
+	if booking.Status == constants.BOOKING_STATUS_HELD {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingOnHold)
+	}
+
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); !ok {
+			return nil, err
+		}
+		// A cart booking is paid together with the rest of the cart
+		if booking.OrderID != nil {
+			return nil, customerror.NewBadRequestError(constants.ErrBookingInCart)
+		}
+
+		quote, err := (*pricingUsecase)(u).quote(ctx, field, booking.StartTime, booking.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		paymentRecord, err = u.Options.Repository.Payment.CreatePayment(ctx, models.Payment{
+			BookingID:      booking.ID,
+			Amount:         quote.Amount,
+			OriginalAmount: quote.Amount,
+			DepositAmount:  depositAmount(field, quote.Amount),
+			Status:         constants.PAYMENT_STATUS_PENDING,
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	duration := booking.EndTime.Sub(booking.StartTime)
-	hours := int(duration.Hours())
-	if hours == 0 {
-		hours = 1
+	// Once paid, only a price increase (e.g. after a reschedule) is left to pay
+	amount := paymentRecord.Amount - paymentRecord.PaidAmount
+	if amount <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentAlreadyProcessed)
+	}
+	if paymentRecord.SplitDeadline != nil && booking.Status == constants.BOOKING_STATUS_PENDING {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentSplit)
+	}
+	// A booking taking a deposit is secured with the deposit first; the
+	// next transaction pays the balance
+	if paymentRecord.PaidAmount < paymentRecord.DepositAmount && paymentRecord.DepositAmount < paymentRecord.Amount {
+		amount = paymentRecord.DepositAmount - paymentRecord.PaidAmount
 	}
-	amount := hours * field.PricePerHour
 
-	paymentRecord := models.Payment{
-		BookingID:     booking.ID,
-		Amount:        amount,
-		Status:        constants.PAYMENT_STATUS_PENDING,
-		PaymentMethod: "",
+	items, err := u.transactionItems(ctx, paymentRecord, booking, field, amount)
+	if err != nil {
+		return nil, err
 	}
 
-	createdPayment, err := u.Options.Repository.Payment.CreatePayment(ctx, paymentRecord)
+	attempt, err := u.createAttempt(ctx, paymentRecord, amount, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	isProduction := u.Options.Config.ServiceEnvironment == "production"
-	paymentService := payment.NewMidtransService(u.Options.Config.MidtransServerKey, isProduction)
+	return u.startTransaction(ctx, paymentRecord, attempt, user, items)
+}
+
+// startTransaction opens the gateway transaction of a new attempt, paid by
+// user. The attempt fails when the gateway refuses the transaction.
+func (u *paymentUsecase) startTransaction(ctx context.Context, paymentRecord models.Payment, attempt models.PaymentAttempt, user models.User, items []payment.Item) (*models.PaymentTransactionResponse, error) {
+	amount := attempt.Amount
+	paymentService := u.Options.PaymentGateway
 
-	itemName := fmt.Sprintf("Booking %s - %s", field.Name, booking.StartTime.Format("02 Jan 2006 15:04"))
 	snapResp, err := paymentService.CreateTransaction(
-		booking.ID.String(),
+		attempt.OrderID,
 		int64(amount),
 		user.Name,
 		user.Email,
-		itemName,
+		items,
 	)
 	if err != nil {
-		u.Options.Repository.Payment.UpdatePaymentStatus(ctx, createdPayment.ID.String(), constants.PAYMENT_STATUS_FAILED)
+		u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), map[string]interface{}{
+			"status": constants.PAYMENT_STATUS_FAILED,
+		})
 		return nil, fmt.Errorf("payment gateway error: %v", err)
 	}
 
 	var transactionID string
-	transactionDetails, err := paymentService.GetTransactionDetails(booking.ID.String())
+	transactionDetails, err := paymentService.GetTransactionDetails(attempt.OrderID)
 	if err != nil {
 		transactionID = "pending"
 	} else {
 		transactionID = transactionDetails.TransactionID
 	}
 
+	err = u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), map[string]interface{}{
+		"snap_token":     snapResp.Token,
+		"redirect_url":   snapResp.RedirectURL,
+		"transaction_id": transactionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	response := &models.PaymentTransactionResponse{
-		PaymentID:     createdPayment.ID,
+		PaymentID:     paymentRecord.ID,
+		AttemptID:     attempt.ID,
+		OrderID:       attempt.OrderID,
 		Token:         snapResp.Token,
 		RedirectURL:   snapResp.RedirectURL,
 		TransactionID: transactionID,
@@ -98,16 +168,133 @@ func (u *paymentUsecase) CreatePaymentTransaction(ctx context.Context, bookingID
 	return response, nil
 }
 
+// transactionItems describes what a transaction of amount pays for. A cart
+// paid in full, or paying its deposits, lists each of its bookings; anything
+// else is a single line, telling a deposit and the balance after it apart.
+func (u *paymentUsecase) transactionItems(ctx context.Context, paymentRecord models.Payment, booking models.Booking, field models.Field, amount int) ([]payment.Item, error) {
+	itemName := fmt.Sprintf("Booking %s - %s", field.Name, booking.StartTime.Format("02 Jan 2006 15:04"))
+	if paymentRecord.SeriesID != nil {
+		itemName = fmt.Sprintf("Recurring booking %s - %s", field.Name, booking.StartTime.Format("Mon 15:04"))
+	}
+	deposit := paymentRecord.DepositAmount > 0 && paymentRecord.DepositAmount < paymentRecord.Amount
+	if deposit {
+		if paymentRecord.PaidAmount < paymentRecord.DepositAmount {
+			itemName = "Deposit " + itemName
+		} else {
+			itemName = "Balance " + itemName
+		}
+	}
+	single := []payment.Item{{ID: paymentRecord.ID.String(), Name: itemName, Price: int64(amount)}}
+
+	if paymentRecord.OrderID == nil || paymentRecord.PaidAmount > 0 {
+		return single, nil
+	}
+
+	bookings, err := u.Options.Repository.Booking.GetOrderBookings(ctx, paymentRecord.OrderID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[uuid.UUID]models.Field{field.ID: field}
+	items := make([]payment.Item, 0, len(bookings))
+	var total int
+	for _, b := range bookings {
+		if b.Status == constants.BOOKING_STATUS_CANCELED {
+			continue
+		}
+		f, ok := fields[b.FieldID]
+		if !ok {
+			f, err = u.Options.Repository.Field.GetFieldByID(ctx, b.FieldID.String())
+			if err != nil {
+				return nil, err
+			}
+			fields[b.FieldID] = f
+		}
+		name, price := fmt.Sprintf("Booking %s - %s", f.Name, b.StartTime.Format("02 Jan 2006 15:04")), b.Price
+		if deposit {
+			price = upFrontAmount(f, b.Price)
+			if price < b.Price {
+				name = "Deposit " + name
+			}
+		}
+		items = append(items, payment.Item{
+			ID:    b.ID.String(),
+			Name:  name,
+			Price: int64(price),
+		})
+		total += price
+	}
+
+	// The gateway needs the lines to add up to the amount charged
+	if total != amount {
+		return single, nil
+	}
+	return items, nil
+}
+
+// HandlePaymentNotification verifies and applies a gateway webhook. The
+// signature and gross amount are checked before anything is trusted, and each
+// distinct notification is applied at most once so retries and replays are
+// harmless.
 func (u *paymentUsecase) HandlePaymentNotification(ctx context.Context, payload map[string]interface{}) error {
-	orderID, ok := payload["order_id"].(string)
-	if !ok {
-		return fmt.Errorf("invalid notification payload: order_id missing")
+	fields := map[string]string{}
+	for _, key := range []string{"order_id", "status_code", "gross_amount", "signature_key"} {
+		fields[key] = payloadString(payload, key)
+		if fields[key] == "" {
+			return customerror.NewBadRequestErrorf(constants.ErrInvalidNotification, key)
+		}
+	}
+	orderID := fields["order_id"]
+
+	if !payment.VerifySignatureKey(orderID, fields["status_code"], fields["gross_amount"], u.Options.Config.MidtransServerKey, fields["signature_key"]) {
+		return customerror.NewUnauthorizedError(constants.ErrInvalidSignature)
+	}
+
+	attempt, err := u.Options.Repository.Payment.GetAttemptByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if !grossAmountMatches(fields["gross_amount"], attempt.Amount) {
+		return customerror.NewBadRequestErrorf(constants.ErrGrossAmountMismatch, fields["gross_amount"], attempt.Amount)
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return customerror.NewBadRequestError(err.Error())
+	}
+
+	record, created, err := u.Options.Repository.Payment.CreateNotification(ctx, models.PaymentNotification{
+		NotificationKey:   notificationKey(payload),
+		OrderID:           orderID,
+		TransactionID:     payloadString(payload, "transaction_id"),
+		TransactionStatus: payloadString(payload, "transaction_status"),
+		StatusCode:        fields["status_code"],
+		GrossAmount:       fields["gross_amount"],
+		Payload:           string(rawPayload),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		log.Printf("duplicate notification for %s ignored", orderID)
+		return nil
+	}
+
+	if err := u.applyNotification(ctx, attempt); err != nil {
+		// Forget the notification so the gateway's retry is processed again
+		_ = u.Options.Repository.Payment.DeleteNotification(ctx, record.ID.String())
+		return err
 	}
 
-	isProduction := u.Options.Config.ServiceEnvironment == "production"
-	paymentService := payment.NewMidtransService(u.Options.Config.MidtransServerKey, isProduction)
+	return nil
+}
 
-	notification, err := paymentService.CheckTransactionStatus(orderID)
+// applyNotification asks the gateway for the attempt's current status, stores
+// it on the attempt and recalculates the payment it belongs to.
+func (u *paymentUsecase) applyNotification(ctx context.Context, attempt models.PaymentAttempt) error {
+	notification, err := u.Options.PaymentGateway.CheckTransactionStatus(attempt.OrderID)
 	if err != nil {
 		return err
 	}
@@ -115,39 +302,332 @@ func (u *paymentUsecase) HandlePaymentNotification(ctx context.Context, payload
 	fmt.Printf(" Notification details - OrderID: %s, Status: %s, Type: %s\n",
 		notification.OrderID, notification.TransactionStatus, notification.PaymentType)
 
-	// Determine statuses
-	var paymentStatus string
-	var bookingStatus string
-
+	var attemptStatus string
 	switch notification.TransactionStatus {
 	case "capture", "settlement":
-		paymentStatus = constants.PAYMENT_STATUS_SUCCESS
-		bookingStatus = constants.BOOKING_STATUS_PAID
-	case "deny", "cancel", "expire", "failure":
-		paymentStatus = constants.PAYMENT_STATUS_FAILED
-		bookingStatus = constants.BOOKING_STATUS_PENDING
-	case "pending":
-		paymentStatus = constants.PAYMENT_STATUS_PENDING
-		bookingStatus = constants.BOOKING_STATUS_PENDING
+		attemptStatus = constants.PAYMENT_STATUS_SUCCESS
+	case "deny", "cancel", "failure":
+		attemptStatus = constants.PAYMENT_STATUS_FAILED
+	case "expire":
+		attemptStatus = constants.PAYMENT_STATUS_EXPIRED
 	default:
-		paymentStatus = constants.PAYMENT_STATUS_PENDING
-		bookingStatus = constants.BOOKING_STATUS_PENDING
+		attemptStatus = constants.PAYMENT_STATUS_PENDING
 	}
 
-	err = u.Options.Repository.Payment.UpdatePaymentStatus(ctx, notification.OrderID, paymentStatus)
+	// Refunds leave the attempt settled; they are booked per refund instead
+	if notification.TransactionStatus == "refund" || notification.TransactionStatus == "partial_refund" {
+		return u.applyRefunds(ctx, attempt, notification.Refunds)
+	}
+
+	// A settled attempt stays settled; late or out-of-order notifications
+	// must not take the money back out of the aggregate.
+	if attempt.Status == constants.PAYMENT_STATUS_SUCCESS && attemptStatus != constants.PAYMENT_STATUS_SUCCESS {
+		return nil
+	}
+
+	updates := map[string]interface{}{"status": attemptStatus}
+	if notification.PaymentType != "" {
+		updates["payment_method"] = notification.PaymentType
+	}
+	if notification.TransactionID != "" {
+		updates["transaction_id"] = notification.TransactionID
+	}
+	if attemptStatus == constants.PAYMENT_STATUS_SUCCESS {
+		updates["paid_at"] = time.Now()
+	}
+
+	if err := u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), updates); err != nil {
+		return err
+	}
+
+	return u.settlePayment(ctx, attempt.PaymentID.String(), models.Actor{Source: constants.STATUS_SOURCE_GATEWAY})
+}
+
+// settlePayment derives the payment's state from its attempts: it is paid once
+// the successful attempts cover the amount, otherwise it follows the latest
+// attempt. A refunded payment keeps its refund status, and a change the
+// payment state machine refuses (e.g. a late notification for an expired
+// payment) leaves the payment as it is. Pending bookings, every booking of
+// a series or cart included, are marked paid together with the payment.
+func (u *paymentUsecase) settlePayment(ctx context.Context, paymentID string, actor models.Actor) error {
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByID(ctx, paymentID)
 	if err != nil {
 		return err
 	}
 
-	if notification.PaymentType != "" {
-		err = u.Options.Repository.Payment.UpdatePaymentMethod(ctx, notification.OrderID, notification.PaymentType)
+	attempts, err := u.Options.Repository.Payment.GetAttemptsByPaymentID(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+
+	status := paymentRecord.Status
+	paidAmount := 0
+	paymentMethod := ""
+	for _, attempt := range attempts {
+		status = attempt.Status
+		if attempt.Status == constants.PAYMENT_STATUS_SUCCESS {
+			paidAmount += attempt.Amount
+			paymentMethod = attempt.PaymentMethod
+		}
+	}
+	if paidAmount >= paymentRecord.Amount {
+		status = constants.PAYMENT_STATUS_SUCCESS
+	} else if status == constants.PAYMENT_STATUS_SUCCESS {
+		status = constants.PAYMENT_STATUS_PENDING
+	}
+	if paymentRecord.RefundedAmount > 0 {
+		status = paymentRecord.Status
+	}
+
+	err = u.Options.Repository.Payment.SettlePayment(ctx, paymentID, models.StatusChange{To: status, Actor: actor}, paidAmount, paymentMethod)
+	if err != nil {
+		if _, ok := err.(customerror.BadRequestError); ok {
+			log.Printf("settling payment %s: %v", paymentID, err)
+			return nil
+		}
+		return err
+	}
+
+	if paymentRecord.SplitDeadline != nil {
+		if err := (*splitPaymentUsecase)(u).settleShares(ctx, paymentRecord, attempts, status); err != nil {
+			return err
+		}
+	}
+
+	// A paid deposit secures the booking until the balance is paid
+	bookingStatus := constants.BOOKING_STATUS_PAID
+	if status != constants.PAYMENT_STATUS_SUCCESS {
+		if paymentRecord.DepositAmount == 0 || paidAmount < paymentRecord.DepositAmount {
+			return nil
+		}
+		bookingStatus = constants.BOOKING_STATUS_PARTIALLY_PAID
+	}
+
+	bookings := []models.Booking{}
+	if paymentRecord.SeriesID != nil {
+		bookings, err = u.Options.Repository.Booking.GetSeriesBookings(ctx, paymentRecord.SeriesID.String())
+		if err != nil {
+			return err
+		}
+	} else if paymentRecord.OrderID != nil {
+		bookings, err = u.Options.Repository.Booking.GetOrderBookings(ctx, paymentRecord.OrderID.String())
 		if err != nil {
+			return err
+		}
+	} else {
+		booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, paymentRecord.BookingID.String())
+		if err != nil {
+			return err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	refunds, err := u.Options.Repository.Refund.GetRefundsByPaymentID(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+	refunded := make(map[uuid.UUID]int)
+	for _, refund := range refunds {
+		if refund.Status == constants.REFUND_STATUS_SUCCESS {
+			refunded[refund.BookingID] += refund.Amount
+		}
+	}
+
+	paymentRecord.PaidAmount = paidAmount
+	for _, booking := range bookings {
+		// A canceled booking stays canceled even if a leftover transaction
+		// settles (e.g. paid right as its hold expired); the money paid for
+		// it goes back.
+		if booking.Status == constants.BOOKING_STATUS_CANCELED {
+			amount := min(bookingShare(paymentRecord, booking)-refunded[booking.ID], paymentRecord.PaidAmount-paymentRecord.RefundedAmount)
+			if amount <= 0 {
+				continue
+			}
+
+			if _, err := u.refund(ctx, paymentRecord, booking.ID, amount, "Booking was canceled before the payment settled", constants.REFUND_SOURCE_CANCELLATION, nil); err != nil {
+				return err
+			}
+			paymentRecord.RefundedAmount += amount
+			continue
 		}
+
+		if statemachine.Booking.Can(booking.Status, bookingStatus) {
+			err := u.Options.Repository.Booking.TransitionBooking(ctx, booking.ID.String(), models.StatusChange{
+				To:    bookingStatus,
+				Actor: actor,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bookingShare is the part of what was paid for the payment that belongs to
+// the booking: all of it, or for a booking of a series or cart the share of
+// its price in the whole. Anything paid beyond the amount was kept as credit
+// when the booking got cheaper and is not part of it.
+func bookingShare(paymentRecord models.Payment, booking models.Booking) int {
+	paid := min(paymentRecord.PaidAmount, paymentRecord.Amount)
+	if (paymentRecord.SeriesID == nil && paymentRecord.OrderID == nil) || paymentRecord.OriginalAmount == 0 {
+		return paid
+	}
+	return paid * booking.Price / paymentRecord.OriginalAmount
+}
+
+// depositAmount is the deposit a booking of field asks for out of amount,
+// rounded up to a whole rupiah. It is 0 when the field takes no deposit or
+// the deposit would be the full amount anyway.
+func depositAmount(field models.Field, amount int) int {
+	if field.DepositPercent <= 0 || field.DepositPercent >= 100 || amount <= 0 {
+		return 0
+	}
+	return (amount*field.DepositPercent + 99) / 100
+}
+
+// upFrontAmount is what a booking of field priced at price asks for before
+// it is secured: its deposit, or the full price on a field taking none.
+func upFrontAmount(field models.Field, price int) int {
+	if deposit := depositAmount(field, price); deposit > 0 {
+		return deposit
+	}
+	return price
+}
+
+// refund returns amount from the payment's settled attempts, newest first,
+// recording one refund per attempt against the booking it is for. Attempts
+// settled outside the gateway (the mock payment endpoint) have no gateway
+// transaction; their refunds are only recorded and paid out manually. A
+// refund the gateway rejects is kept as failed and stops the run; the
+// refunds made until then are returned.
+func (u *paymentUsecase) refund(ctx context.Context, paymentRecord models.Payment, bookingID uuid.UUID, amount int, reason, source string, requestedBy *uuid.UUID) ([]models.Refund, error) {
+	attempts, err := u.Options.Repository.Payment.GetAttemptsByPaymentID(ctx, paymentRecord.ID.String())
+	if err != nil {
+		return nil, err
 	}
 
-	if paymentStatus == constants.PAYMENT_STATUS_SUCCESS {
-		err = u.Options.Repository.Booking.UpdateBookingStatus(ctx, notification.OrderID, bookingStatus)
+	var refunds []models.Refund
+	remaining := amount
+	for i := len(attempts) - 1; i >= 0 && remaining > 0; i-- {
+		attempt := attempts[i]
+		if attempt.Status != constants.PAYMENT_STATUS_SUCCESS {
+			continue
+		}
+
+		share := min(remaining, attempt.Amount-attempt.RefundedAmount)
+		if share <= 0 {
+			continue
+		}
+
+		refund, err := u.Options.Repository.Refund.CreateRefund(ctx, models.Refund{
+			PaymentID:   paymentRecord.ID,
+			AttemptID:   attempt.ID,
+			BookingID:   bookingID,
+			Amount:      share,
+			Reason:      reason,
+			RefundKey:   fmt.Sprintf("%s-r%d", attempt.OrderID, attempt.RefundedAmount+share),
+			Source:      source,
+			RequestedBy: requestedBy,
+		})
 		if err != nil {
+			return refunds, err
+		}
+
+		gatewayRefundID := ""
+		if attempt.TransactionID != "" {
+			resp, err := u.Options.PaymentGateway.Refund(attempt.OrderID, refund.RefundKey, int64(share), reason)
+			if err != nil {
+				if failErr := u.Options.Repository.Refund.FailRefund(ctx, refund.ID.String(), err.Error()); failErr != nil {
+					log.Printf("marking refund %s failed: %v", refund.RefundKey, failErr)
+				}
+				return refunds, customerror.NewInternalServiceErrorf(constants.ErrRefundRejected, err)
+			}
+			gatewayRefundID = refundChargebackID(resp.RefundChargebackID)
+		}
+
+		if err := u.completeRefund(ctx, refund, gatewayRefundID); err != nil {
+			return refunds, err
+		}
+
+		refund.Status = constants.REFUND_STATUS_SUCCESS
+		refunds = append(refunds, refund)
+		remaining -= share
+	}
+
+	return refunds, nil
+}
+
+// completeRefund marks the refund successful and books its amount on the
+// attempt and the payment. The gateway's notification may complete the
+// refund before the refund call returns; whichever comes second is a no-op.
+func (u *paymentUsecase) completeRefund(ctx context.Context, refund models.Refund, gatewayRefundID string) error {
+	return u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		completed, err := tx.Refund.CompleteRefund(ctx, refund.ID.String(), gatewayRefundID)
+		if err != nil || !completed {
+			return err
+		}
+
+		return tx.Payment.AddRefund(ctx, refund.PaymentID.String(), refund.AttemptID.String(), refund.Amount, refundActor(refund), refund.Reason)
+	})
+}
+
+// refundActor is what started the refund: the admin who requested it, the
+// gateway, or the system refunding a cancellation.
+func refundActor(refund models.Refund) models.Actor {
+	switch refund.Source {
+	case constants.REFUND_SOURCE_ADMIN:
+		return models.Actor{Source: constants.STATUS_SOURCE_ADMIN, ID: refund.RequestedBy}
+	case constants.REFUND_SOURCE_GATEWAY:
+		return models.Actor{Source: constants.STATUS_SOURCE_GATEWAY}
+	default:
+		return models.Actor{Source: constants.STATUS_SOURCE_SYSTEM}
+	}
+}
+
+// applyRefunds records the refunds the gateway reports for a settled
+// attempt. Refunds started here are matched by their key; refunds made on
+// the gateway's side, e.g. from its dashboard, are added as gateway refunds.
+func (u *paymentUsecase) applyRefunds(ctx context.Context, attempt models.PaymentAttempt, details []coreapi.RefundDetails) error {
+	if attempt.Status != constants.PAYMENT_STATUS_SUCCESS {
+		log.Printf("refund notification for unsettled transaction %s ignored", attempt.OrderID)
+		return nil
+	}
+
+	for _, detail := range details {
+		gatewayRefundID := refundChargebackID(detail.RefundChargebackID)
+		refundKey := detail.RefundKey
+		if refundKey == "" {
+			refundKey = fmt.Sprintf("%s-cb%s", attempt.OrderID, gatewayRefundID)
+		}
+
+		refund, err := u.Options.Repository.Refund.GetRefundByKey(ctx, refundKey)
+		if err != nil {
+			if _, ok := err.(customerror.NotFoundError); !ok {
+				return err
+			}
+
+			amount, ok := gatewayAmount(detail.RefundAmount)
+			if !ok || amount <= 0 {
+				return customerror.NewBadRequestErrorf(constants.ErrInvalidGatewayAmount, detail.RefundAmount)
+			}
+
+			refund, err = u.Options.Repository.Refund.CreateRefund(ctx, models.Refund{
+				PaymentID: attempt.PaymentID,
+				AttemptID: attempt.ID,
+				BookingID: attempt.BookingID,
+				Amount:    amount,
+				Reason:    detail.Reason,
+				RefundKey: refundKey,
+				Source:    constants.REFUND_SOURCE_GATEWAY,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := u.completeRefund(ctx, refund, gatewayRefundID); err != nil {
 			return err
 		}
 	}
@@ -155,43 +635,213 @@ func (u *paymentUsecase) HandlePaymentNotification(ctx context.Context, payload
 	return nil
 }
 
-func (u *paymentUsecase) ProcessPayment(ctx context.Context, bookingID string, req models.CreatePaymentRequest) (*models.PaymentResponse, error) {
-	payment, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+// RefundPayment refunds part or all of what is left of a payment on behalf
+// of an admin. The booking itself is left as it is; cancel it to free the
+// slot.
+func (u *paymentUsecase) RefundPayment(ctx context.Context, bookingID, adminID string, req models.RefundRequest) (*models.PaymentResponse, error) {
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
 	if err != nil {
 		return nil, err
 	}
-	if payment.Status == constants.PAYMENT_STATUS_SUCCESS {
-		return nil, fmt.Errorf(constants.ErrPaymentAlreadyProcessed)
+
+	refundable := paymentRecord.PaidAmount - paymentRecord.RefundedAmount
+	if refundable <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrNothingToRefund)
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount < 0 || amount > refundable {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidRefundAmount, refundable)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "Refunded by admin"
+	}
+
+	requestedBy := helpers.ParseUUID(adminID)
+	if _, err := u.refund(ctx, paymentRecord, helpers.ParseUUID(bookingID), amount, reason, constants.REFUND_SOURCE_ADMIN, &requestedBy); err != nil {
+		return nil, err
 	}
-	err = u.Options.Repository.Payment.ProcessPayment(ctx, payment.ID.String())
+
+	return u.GetPaymentByBookingID(ctx, bookingID)
+}
+
+// RecordCashPayment records cash an admin took at the counter for part or
+// all of what is still due on a booking, typically the balance after a
+// deposit. Open gateway transactions were opened for the old balance and
+// are expired first.
+func (u *paymentUsecase) RecordCashPayment(ctx context.Context, bookingID, adminID string, req models.CashPaymentRequest) (*models.PaymentResponse, error) {
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = u.Options.Repository.Payment.UpdatePaymentMethod(ctx, payment.ID.String(), req.PaymentMethod)
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
 	if err != nil {
+		return nil, err
+	}
+	if booking.Status == constants.BOOKING_STATUS_CANCELED {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingCanceled)
+	}
+
+	balance := paymentRecord.Amount - paymentRecord.PaidAmount
+	if balance <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentAlreadyProcessed)
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = balance
+	}
+	if amount < 0 || amount > balance {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidCashAmount, balance)
+	}
+
+	if err := u.expireAttempts(ctx, paymentRecord); err != nil {
+		return nil, err
 	}
 
-	err = u.Options.Repository.Booking.UpdateBookingStatus(ctx, bookingID, constants.BOOKING_STATUS_PAID)
+	attempt, err := u.createAttempt(ctx, paymentRecord, amount, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	updatedPayment, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	err = u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), map[string]interface{}{
+		"status":         constants.PAYMENT_STATUS_SUCCESS,
+		"payment_method": constants.PAYMENT_METHOD_CASH,
+		"paid_at":        time.Now(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	paymentResponse := &models.PaymentResponse{
-		ID:            updatedPayment.ID,
-		BookingID:     updatedPayment.BookingID,
-		Amount:        updatedPayment.Amount,
-		Status:        updatedPayment.Status,
-		PaymentMethod: updatedPayment.PaymentMethod,
-		PaidAt:        updatedPayment.PaidAt,
-		CreatedAt:     updatedPayment.CreatedAt,
+	if err := u.settlePayment(ctx, paymentRecord.ID.String(), adminActor(adminID)); err != nil {
+		return nil, err
 	}
-	return paymentResponse, nil
+
+	return u.GetPaymentByBookingID(ctx, bookingID)
+}
+
+// expire closes the unpaid payment of an expired booking: its pending
+// attempts are expired at the gateway so they can no longer be paid, and the
+// payment is marked expired.
+func (u *paymentUsecase) expire(ctx context.Context, bookingID string) error {
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	if err := u.expireAttempts(ctx, paymentRecord); err != nil {
+		return err
+	}
+
+	err = u.Options.Repository.Payment.SettlePayment(ctx, paymentRecord.ID.String(), models.StatusChange{
+		To:     constants.PAYMENT_STATUS_EXPIRED,
+		Actor:  models.Actor{Source: constants.STATUS_SOURCE_SYSTEM},
+		Reason: "Payment hold expired",
+	}, paymentRecord.PaidAmount, "")
+	if err != nil || paymentRecord.SplitDeadline == nil {
+		return err
+	}
+
+	// The shares paid before the split deadline passed go back
+	if refundable := paymentRecord.PaidAmount - paymentRecord.RefundedAmount; refundable > 0 {
+		_, err := u.refund(ctx, paymentRecord, paymentRecord.BookingID, refundable, "Split payment deadline passed", constants.REFUND_SOURCE_CANCELLATION, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return u.Options.Repository.Share.CloseShares(ctx, paymentRecord.ID.String(), constants.SHARE_STATUS_CANCELED)
+}
+
+// expireAttempts expires the payment's pending attempts at the gateway so
+// they can no longer be paid.
+func (u *paymentUsecase) expireAttempts(ctx context.Context, paymentRecord models.Payment) error {
+	attempts, err := u.Options.Repository.Payment.GetAttemptsByPaymentID(ctx, paymentRecord.ID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range attempts {
+		if attempt.Status != constants.PAYMENT_STATUS_PENDING {
+			continue
+		}
+		if err := u.expireAttempt(ctx, attempt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expireAttempt expires a pending attempt at the gateway and here.
+func (u *paymentUsecase) expireAttempt(ctx context.Context, attempt models.PaymentAttempt) error {
+	// Transactions the customer never opened are unknown to the gateway;
+	// they cannot be paid anymore either way.
+	if err := u.Options.PaymentGateway.Expire(attempt.OrderID); err != nil {
+		log.Printf("expiring transaction %s: %v", attempt.OrderID, err)
+	}
+
+	return u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), map[string]interface{}{
+		"status": constants.PAYMENT_STATUS_EXPIRED,
+	})
+}
+
+// createAttempt records a pending attempt of amount, which the repository
+// gives the next order ID of the payment.
+func (u *paymentUsecase) createAttempt(ctx context.Context, paymentRecord models.Payment, amount int, shareID *uuid.UUID) (models.PaymentAttempt, error) {
+	return u.Options.Repository.Payment.CreateAttempt(ctx, models.PaymentAttempt{
+		PaymentID: paymentRecord.ID,
+		BookingID: paymentRecord.BookingID,
+		ShareID:   shareID,
+		Amount:    amount,
+		Status:    constants.PAYMENT_STATUS_PENDING,
+	})
+}
+
+func (u *paymentUsecase) ProcessPayment(ctx context.Context, bookingID string, actor models.Actor, req models.CreatePaymentRequest) (*models.PaymentResponse, error) {
+	payment, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Amount-payment.PaidAmount <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentAlreadyProcessed)
+	}
+
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status == constants.BOOKING_STATUS_CANCELED {
+		return nil, customerror.NewBadRequestError(constants.ErrBookingCanceled)
+	}
+
+	attempt, err := u.createAttempt(ctx, payment, payment.Amount-payment.PaidAmount, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Options.Repository.Payment.UpdateAttempt(ctx, attempt.ID.String(), map[string]interface{}{
+		"status":         constants.PAYMENT_STATUS_SUCCESS,
+		"payment_method": req.PaymentMethod,
+		"paid_at":        time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.settlePayment(ctx, payment.ID.String(), actor); err != nil {
+		return nil, err
+	}
+
+	return u.GetPaymentByBookingID(ctx, bookingID)
 }
 
 func (u *paymentUsecase) GetPaymentByBookingID(ctx context.Context, bookingID string) (*models.PaymentResponse, error) {
@@ -199,15 +849,158 @@ func (u *paymentUsecase) GetPaymentByBookingID(ctx context.Context, bookingID st
 	if err != nil {
 		return nil, err
 	}
-	paymentResponse := &models.PaymentResponse{
-		ID:            payment.ID,
-		BookingID:     payment.BookingID,
-		Amount:        payment.Amount,
-		Status:        payment.Status,
-		PaymentMethod: payment.PaymentMethod,
-		PaidAt:        payment.PaidAt,
-		CreatedAt:     payment.CreatedAt,
+
+	attempts, err := u.Options.Repository.Payment.GetAttemptsByPaymentID(ctx, payment.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := u.Options.Repository.Refund.GetRefundsByPaymentID(ctx, payment.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	paymentResponse := toPaymentResponse(payment)
+	for _, attempt := range attempts {
+		paymentResponse.Attempts = append(paymentResponse.Attempts, models.PaymentAttemptResponse{
+			ID:             attempt.ID,
+			ShareID:        attempt.ShareID,
+			OrderID:        attempt.OrderID,
+			Amount:         attempt.Amount,
+			RefundedAmount: attempt.RefundedAmount,
+			Status:         attempt.Status,
+			PaymentMethod:  attempt.PaymentMethod,
+			RedirectURL:    attempt.RedirectURL,
+			PaidAt:         attempt.PaidAt,
+			CreatedAt:      attempt.CreatedAt,
+		})
+	}
+	for _, refund := range refunds {
+		paymentResponse.Refunds = append(paymentResponse.Refunds, models.RefundResponse{
+			ID:            refund.ID,
+			AttemptID:     refund.AttemptID,
+			Amount:        refund.Amount,
+			Reason:        refund.Reason,
+			Status:        refund.Status,
+			Source:        refund.Source,
+			RequestedBy:   refund.RequestedBy,
+			FailureReason: refund.FailureReason,
+			CreatedAt:     refund.CreatedAt,
+		})
+	}
+
+	if payment.SplitDeadline != nil {
+		shares, err := u.Options.Repository.Share.GetSharesByPaymentID(ctx, payment.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, share := range shares {
+			paymentResponse.Shares = append(paymentResponse.Shares, toPaymentShareResponse(share))
+		}
 	}
 
 	return paymentResponse, nil
 }
+
+// toPaymentResponse describes the payment itself, without its attempts,
+// refunds or shares.
+func toPaymentResponse(payment models.Payment) *models.PaymentResponse {
+	return &models.PaymentResponse{
+		ID:             payment.ID,
+		BookingID:      payment.BookingID,
+		SeriesID:       payment.SeriesID,
+		OrderID:        payment.OrderID,
+		OriginalAmount: payment.OriginalAmount,
+		DiscountAmount: payment.DiscountAmount,
+		Amount:         payment.Amount,
+		VoucherID:      payment.VoucherID,
+		PaidAmount:     payment.PaidAmount,
+		RefundedAmount: payment.RefundedAmount,
+		Status:         payment.Status,
+		PaymentMethod:  payment.PaymentMethod,
+		PaidAt:         payment.PaidAt,
+		SplitDeadline:  payment.SplitDeadline,
+		DepositAmount:  payment.DepositAmount,
+		CreatedAt:      payment.CreatedAt,
+	}
+}
+
+// GetFakeTransaction shows a transaction held by the offline fake gateway.
+func (u *paymentUsecase) GetFakeTransaction(ctx context.Context, orderID string) (*coreapi.TransactionStatusResponse, error) {
+	if _, ok := u.Options.PaymentGateway.(payment.Simulator); !ok {
+		return nil, customerror.NewNotFoundError(constants.ErrFakeGatewayDisabled)
+	}
+
+	transaction, err := u.Options.PaymentGateway.GetTransactionDetails(orderID)
+	if err != nil {
+		return nil, customerror.NewNotFoundError(err.Error())
+	}
+	return transaction, nil
+}
+
+// SimulatePayment drives a fake gateway transaction to a final state and feeds
+// the resulting notification through the regular webhook handling.
+func (u *paymentUsecase) SimulatePayment(ctx context.Context, orderID string, req models.SimulatePaymentRequest) error {
+	simulator, ok := u.Options.PaymentGateway.(payment.Simulator)
+	if !ok {
+		return customerror.NewNotFoundError(constants.ErrFakeGatewayDisabled)
+	}
+
+	payload, err := simulator.Simulate(orderID, req.TransactionStatus, req.PaymentType)
+	if err != nil {
+		return customerror.NewBadRequestError(err.Error())
+	}
+
+	return u.HandlePaymentNotification(ctx, payload)
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	switch v := payload[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// grossAmountMatches compares Midtrans' decimal gross_amount ("150000.00")
+// with the stored integer amount.
+func grossAmountMatches(grossAmount string, amount int) bool {
+	value, ok := gatewayAmount(grossAmount)
+	return ok && value == amount
+}
+
+// gatewayAmount parses a decimal amount reported by Midtrans ("150000.00").
+func gatewayAmount(value string) (int, bool) {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(math.Round(amount)), true
+}
+
+func refundChargebackID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// notificationKey identifies a notification delivery independent of retries:
+// the same transaction reaching the same status always yields the same key.
+// Refund notifications also carry the total refunded so far, which tells
+// successive partial refunds apart.
+func notificationKey(payload map[string]interface{}) string {
+	parts := []string{}
+	for _, key := range []string{"order_id", "transaction_id", "transaction_status", "status_code", "fraud_status", "gross_amount"} {
+		parts = append(parts, payloadString(payload, key))
+	}
+	if refundAmount := payloadString(payload, "refund_amount"); refundAmount != "" {
+		parts = append(parts, refundAmount)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
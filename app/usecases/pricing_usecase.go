@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type pricingUsecase usecase
+
+// maxQuoteDays bounds the range a quote may cover.
+const maxQuoteDays = 31
+
+type PricingInterface interface {
+	GetPricingRules(ctx context.Context, fieldID string) ([]models.PricingRuleResponse, error)
+	CreatePricingRule(ctx context.Context, fieldID string, req models.PricingRuleRequest) (*models.PricingRuleResponse, error)
+	UpdatePricingRule(ctx context.Context, fieldID, ruleID string, req models.PricingRuleRequest) (*models.PricingRuleResponse, error)
+	DeletePricingRule(ctx context.Context, fieldID, ruleID string) error
+	GetQuote(ctx context.Context, fieldID string, req models.PriceQuoteRequest) (*models.PriceQuoteResponse, error)
+}
+
+func (u *pricingUsecase) GetPricingRules(ctx context.Context, fieldID string) ([]models.PricingRuleResponse, error) {
+	if _, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID); err != nil {
+		return nil, err
+	}
+
+	rules, err := u.Options.Repository.PricingRule.GetRulesByFieldID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.PricingRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, toPricingRuleResponse(rule))
+	}
+	return responses, nil
+}
+
+func (u *pricingUsecase) CreatePricingRule(ctx context.Context, fieldID string, req models.PricingRuleRequest) (*models.PricingRuleResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := newPricingRule(field.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err = u.Options.Repository.PricingRule.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPricingRuleResponse(rule)
+	return &response, nil
+}
+
+func (u *pricingUsecase) UpdatePricingRule(ctx context.Context, fieldID, ruleID string, req models.PricingRuleRequest) (*models.PricingRuleResponse, error) {
+	existing, err := u.Options.Repository.PricingRule.GetRuleByID(ctx, fieldID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := newPricingRule(existing.FieldID, req)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = existing.ID
+	rule.CreatedAt = existing.CreatedAt
+
+	rule, err = u.Options.Repository.PricingRule.UpdateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPricingRuleResponse(rule)
+	return &response, nil
+}
+
+func (u *pricingUsecase) DeletePricingRule(ctx context.Context, fieldID, ruleID string) error {
+	return u.Options.Repository.PricingRule.DeleteRule(ctx, fieldID, ruleID)
+}
+
+// GetQuote prices a booking of the field from start to end without creating
+// it. Opening hours and existing bookings are not checked.
+func (u *pricingUsecase) GetQuote(ctx context.Context, fieldID string, req models.PriceQuoteRequest) (*models.PriceQuoteResponse, error) {
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Start == "" || req.End == "" {
+		return nil, customerror.NewBadRequestError(constants.ErrQuoteTimeRequired)
+	}
+
+	start, err := parseTime(req.Start)
+	if err != nil {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "start")
+	}
+
+	end, err := parseTime(req.End)
+	if err != nil {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrInvalidDateTime, "end")
+	}
+
+	if !end.After(start) {
+		return nil, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	}
+	if end.Sub(start) > maxQuoteDays*24*time.Hour {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrQuoteRangeTooLong, maxQuoteDays)
+	}
+
+	return u.quote(ctx, field, start, end)
+}
+
+// quote prices [start, end) minute by minute: each minute costs the hourly
+// price of the highest-priority rule matching it, or the field's base price
+// when none does, so a 90 minute booking pays for exactly 90 minutes.
+// Consecutive minutes priced by the same rule form one line, rounded to a
+// whole amount; the total is the sum of the lines.
+func (u *pricingUsecase) quote(ctx context.Context, field models.Field, start, end time.Time) (*models.PriceQuoteResponse, error) {
+	loc := u.Options.Config.GetVenueLocation()
+
+	rules, err := u.Options.Repository.PricingRule.GetRulesByFieldID(ctx, field.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make(map[string]bool)
+	if slices.ContainsFunc(rules, func(rule models.PricingRule) bool { return rule.DayType == constants.PRICING_DAY_HOLIDAY }) {
+		firstDay, lastDay := holidayDate(start, loc), holidayDate(end.Add(-time.Nanosecond), loc)
+		dates, err := u.Options.Repository.Holiday.GetHolidays(ctx, &firstDay, &lastDay)
+		if err != nil {
+			return nil, err
+		}
+		for _, holiday := range dates {
+			holidays[holiday.Date.UTC().Format(constants.DATE_FORMAT)] = true
+		}
+	}
+
+	response := &models.PriceQuoteResponse{
+		FieldID:   field.ID,
+		StartTime: start.In(loc),
+		EndTime:   end.In(loc),
+		Minutes:   int(end.Sub(start) / time.Minute),
+		Lines:     []models.PriceQuoteLine{},
+	}
+
+	var (
+		line    *models.PriceQuoteLine
+		current *models.PricingRule
+	)
+	flush := func() {
+		if line == nil {
+			return
+		}
+		duration := line.EndTime.Sub(line.StartTime)
+		line.Minutes = int(duration / time.Minute)
+		line.Amount = prorate(line.PricePerHour, duration)
+		response.Lines = append(response.Lines, *line)
+		response.Amount += line.Amount
+	}
+
+	for cursor := start; cursor.Before(end); {
+		next := cursor.Truncate(time.Minute).Add(time.Minute)
+		if next.After(end) {
+			next = end
+		}
+
+		rule := matchPricingRule(rules, cursor.In(loc), holidays)
+		if line == nil || rule != current {
+			flush()
+			line = &models.PriceQuoteLine{StartTime: cursor.In(loc), PricePerHour: field.PricePerHour}
+			if rule != nil {
+				line.PricePerHour, line.RuleID, line.RuleName = rule.PricePerHour, &rule.ID, rule.Name
+			}
+			current = rule
+		}
+		line.EndTime = next.In(loc)
+		cursor = next
+	}
+	flush()
+
+	return response, nil
+}
+
+// prorate charges pricePerHour for duration, rounded to the nearest unit.
+func prorate(pricePerHour int, duration time.Duration) int {
+	seconds := int64(duration / time.Second)
+	return int((int64(pricePerHour)*seconds + 1800) / 3600)
+}
+
+// matchPricingRule returns the rule pricing the minute starting at t (in the
+// venue timezone), or nil for the base price. Among matching rules the
+// highest priority wins, then the most specific day type.
+func matchPricingRule(rules []models.PricingRule, t time.Time, holidays map[string]bool) *models.PricingRule {
+	minute := t.Hour()*60 + t.Minute()
+
+	var best *models.PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		starts, _ := clockMinutes(rule.StartTime)
+		ends, _ := clockMinutes(rule.EndTime)
+		if minute < starts || minute >= ends || !pricingDayMatches(rule.DayType, t, holidays) {
+			continue
+		}
+
+		if best == nil || rule.Priority > best.Priority ||
+			(rule.Priority == best.Priority && dayTypeSpecificity(rule.DayType) > dayTypeSpecificity(best.DayType)) {
+			best = rule
+		}
+	}
+	return best
+}
+
+func pricingDayMatches(dayType string, t time.Time, holidays map[string]bool) bool {
+	weekend := t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+
+	switch dayType {
+	case constants.PRICING_DAY_ALL:
+		return true
+	case constants.PRICING_DAY_WEEKDAY:
+		return !weekend
+	case constants.PRICING_DAY_WEEKEND:
+		return weekend
+	case constants.PRICING_DAY_HOLIDAY:
+		return holidays[t.Format(constants.DATE_FORMAT)]
+	default:
+		return dayType == strings.ToLower(t.Weekday().String())
+	}
+}
+
+func dayTypeSpecificity(dayType string) int {
+	switch dayType {
+	case constants.PRICING_DAY_ALL:
+		return 0
+	case constants.PRICING_DAY_WEEKDAY, constants.PRICING_DAY_WEEKEND:
+		return 1
+	case constants.PRICING_DAY_HOLIDAY:
+		return 3
+	default:
+		return 2
+	}
+}
+
+func newPricingRule(fieldID uuid.UUID, req models.PricingRuleRequest) (models.PricingRule, error) {
+	dayType := strings.ToLower(strings.TrimSpace(req.DayType))
+	if dayType == "" {
+		dayType = constants.PRICING_DAY_ALL
+	}
+	validDayTypes := []string{constants.PRICING_DAY_ALL, constants.PRICING_DAY_WEEKDAY, constants.PRICING_DAY_WEEKEND, constants.PRICING_DAY_HOLIDAY}
+	if !slices.Contains(validDayTypes, dayType) && !slices.Contains(constants.ArrayDays, dayType) {
+		return models.PricingRule{}, customerror.NewBadRequestErrorf(constants.ErrInvalidDayType, req.DayType)
+	}
+
+	startTime, endTime := req.StartTime, req.EndTime
+	if startTime == "" && endTime == "" {
+		startTime, endTime = "00:00", "24:00"
+	}
+	starts, startsOK := clockMinutes(startTime)
+	ends, endsOK := clockMinutes(endTime)
+	if !startsOK || !endsOK || starts == minutesPerDay || ends <= starts {
+		return models.PricingRule{}, customerror.NewBadRequestErrorf(constants.ErrInvalidPricingTime, startTime, endTime)
+	}
+
+	if req.PricePerHour < 0 {
+		return models.PricingRule{}, customerror.NewBadRequestError(constants.ErrInvalidRulePrice)
+	}
+
+	return models.PricingRule{
+		FieldID:      fieldID,
+		Name:         strings.TrimSpace(req.Name),
+		DayType:      dayType,
+		StartTime:    formatClock(starts),
+		EndTime:      formatClock(ends),
+		PricePerHour: req.PricePerHour,
+		Priority:     req.Priority,
+	}, nil
+}
+
+func toPricingRuleResponse(rule models.PricingRule) models.PricingRuleResponse {
+	return models.PricingRuleResponse{
+		ID:           rule.ID,
+		FieldID:      rule.FieldID,
+		Name:         rule.Name,
+		DayType:      rule.DayType,
+		StartTime:    rule.StartTime,
+		EndTime:      rule.EndTime,
+		PricePerHour: rule.PricePerHour,
+		Priority:     rule.Priority,
+	}
+}
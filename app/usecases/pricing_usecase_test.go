@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/config"
+
+	"github.com/google/uuid"
+)
+
+// fakePricingRules serves the rules of every field from memory.
+type fakePricingRules struct {
+	repositories.PricingRuleInterface
+	rules []models.PricingRule
+}
+
+func (f fakePricingRules) GetRulesByFieldID(ctx context.Context, fieldID string) ([]models.PricingRule, error) {
+	return f.rules, nil
+}
+
+// fakeHolidays serves the holidays between from and to, like the repository.
+type fakeHolidays struct {
+	repositories.HolidayInterface
+	dates []string
+}
+
+func (f fakeHolidays) GetHolidays(ctx context.Context, from, to *time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	for _, value := range f.dates {
+		date, err := time.Parse(constants.DATE_FORMAT, value)
+		if err != nil {
+			return nil, err
+		}
+		if date.Before(*from) || date.After(*to) {
+			continue
+		}
+		holidays = append(holidays, models.Holiday{Date: date})
+	}
+	return holidays, nil
+}
+
+func rule(name, dayType, startTime, endTime string, pricePerHour, priority int) models.PricingRule {
+	return models.PricingRule{
+		ID:           uuid.New(),
+		Name:         name,
+		DayType:      dayType,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		PricePerHour: pricePerHour,
+		Priority:     priority,
+	}
+}
+
+func TestQuote(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("loading venue timezone: %v", err)
+	}
+	at := func(value string) time.Time {
+		t.Helper()
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", value, err)
+		}
+		return parsed
+	}
+
+	// 2026-11-02 is a Monday and 2026-11-07 a Saturday.
+	tests := []struct {
+		name         string
+		pricePerHour int
+		rules        []models.PricingRule
+		holidays     []string
+		start, end   time.Time
+		wantLines    []int
+		wantAmount   int
+	}{
+		{
+			name:         "90 minutes at the base price",
+			pricePerHour: 100000,
+			start:        at("2026-11-02 10:00"),
+			end:          at("2026-11-02 11:30"),
+			wantLines:    []int{150000},
+			wantAmount:   150000,
+		},
+		{
+			name:         "crossing into a peak rule",
+			pricePerHour: 100000,
+			rules:        []models.PricingRule{rule("Peak", constants.PRICING_DAY_ALL, "17:00", "24:00", 150000, 0)},
+			start:        at("2026-11-02 16:30"),
+			end:          at("2026-11-02 18:00"),
+			wantLines:    []int{50000, 150000},
+			wantAmount:   200000,
+		},
+		{
+			name:         "rule running until midnight",
+			pricePerHour: 100000,
+			rules:        []models.PricingRule{rule("Late", constants.PRICING_DAY_ALL, "22:00", "24:00", 200000, 0)},
+			start:        at("2026-11-02 23:00"),
+			end:          at("2026-11-03 00:30"),
+			wantLines:    []int{200000, 50000},
+			wantAmount:   250000,
+		},
+		{
+			name:         "holiday beats weekend at the same priority",
+			pricePerHour: 100000,
+			rules: []models.PricingRule{
+				rule("Weekend", constants.PRICING_DAY_WEEKEND, "00:00", "24:00", 130000, 0),
+				rule("Holiday", constants.PRICING_DAY_HOLIDAY, "00:00", "24:00", 200000, 0),
+			},
+			holidays:   []string{"2026-11-07"},
+			start:      at("2026-11-07 10:00"),
+			end:        at("2026-11-07 11:00"),
+			wantLines:  []int{200000},
+			wantAmount: 200000,
+		},
+		{
+			name:         "holiday looked up in the venue timezone",
+			pricePerHour: 100000,
+			rules: []models.PricingRule{
+				rule("Weekend", constants.PRICING_DAY_WEEKEND, "00:00", "24:00", 130000, 0),
+				rule("Holiday", constants.PRICING_DAY_HOLIDAY, "00:00", "24:00", 200000, 0),
+			},
+			holidays: []string{"2026-11-07"},
+			// Saturday 00:00-01:00 in Jakarta is still Friday in UTC
+			start:      time.Date(2026, 11, 6, 17, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, 11, 6, 18, 0, 0, 0, time.UTC),
+			wantLines:  []int{200000},
+			wantAmount: 200000,
+		},
+		{
+			name:         "non-whole hourly rate rounds the line",
+			pricePerHour: 100001,
+			start:        at("2026-11-02 10:00"),
+			end:          at("2026-11-02 11:30"),
+			wantLines:    []int{150002},
+			wantAmount:   150002,
+		},
+		{
+			name:         "every line is rounded on its own",
+			pricePerHour: 99999,
+			rules:        []models.PricingRule{rule("Peak", constants.PRICING_DAY_ALL, "17:00", "24:00", 100001, 0)},
+			start:        at("2026-11-02 16:30"),
+			end:          at("2026-11-02 17:30"),
+			wantLines:    []int{50000, 50001},
+			wantAmount:   100001,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &pricingUsecase{Options: Options{
+				Repository: &repositories.Main{
+					PricingRule: fakePricingRules{rules: tt.rules},
+					Holiday:     fakeHolidays{dates: tt.holidays},
+				},
+				Config: &config.Config{VenueTimezone: "Asia/Jakarta"},
+			}}
+			field := models.Field{ID: uuid.New(), PricePerHour: tt.pricePerHour}
+
+			quote, err := u.quote(context.Background(), field, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("quote: %v", err)
+			}
+
+			if quote.Amount != tt.wantAmount {
+				t.Errorf("amount = %d, want %d", quote.Amount, tt.wantAmount)
+			}
+			if len(quote.Lines) != len(tt.wantLines) {
+				t.Fatalf("got %d lines, want %d: %+v", len(quote.Lines), len(tt.wantLines), quote.Lines)
+			}
+			for i, line := range quote.Lines {
+				if line.Amount != tt.wantLines[i] {
+					t.Errorf("line %d amount = %d, want %d", i, line.Amount, tt.wantLines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		pricePerHour int
+		duration     time.Duration
+		want         int
+	}{
+		{100000, time.Hour, 100000},
+		{100000, 90 * time.Minute, 150000},
+		{100000, time.Minute, 1667},
+		{100001, 30 * time.Minute, 50001},
+		{99999, 30 * time.Minute, 50000},
+		{0, time.Hour, 0},
+	}
+
+	for _, tt := range tests {
+		if got := prorate(tt.pricePerHour, tt.duration); got != tt.want {
+			t.Errorf("prorate(%d, %s) = %d, want %d", tt.pricePerHour, tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPricingRule(t *testing.T) {
+	monday := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 11, 7, 0, 0, 0, 0, time.UTC)
+	holidays := map[string]bool{"2026-11-07": true}
+
+	weekday := rule("Weekday", constants.PRICING_DAY_WEEKDAY, "00:00", "24:00", 110000, 0)
+	mondays := rule("Monday", "monday", "00:00", "24:00", 120000, 0)
+	urgent := rule("Urgent", constants.PRICING_DAY_WEEKDAY, "18:00", "20:00", 130000, 1)
+	weekend := rule("Weekend", constants.PRICING_DAY_WEEKEND, "00:00", "24:00", 140000, 0)
+	holiday := rule("Holiday", constants.PRICING_DAY_HOLIDAY, "00:00", "24:00", 150000, 0)
+	late := rule("Late", constants.PRICING_DAY_ALL, "22:00", "24:00", 160000, 2)
+
+	rules := []models.PricingRule{weekday, mondays, urgent, weekend, holiday, late}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"day of the week beats weekday", monday.Add(10 * time.Hour), "Monday"},
+		{"priority beats specificity", monday.Add(19 * time.Hour), "Urgent"},
+		{"end time is exclusive", monday.Add(20 * time.Hour), "Monday"},
+		{"rule runs until midnight", monday.Add(23*time.Hour + 59*time.Minute), "Late"},
+		{"holiday beats weekend", saturday.Add(10 * time.Hour), "Holiday"},
+		{"weekend without holiday", saturday.Add(8 * 24 * time.Hour), "Weekend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchPricingRule(rules, tt.t, holidays)
+			if got == nil {
+				t.Fatalf("no rule matched, want %s", tt.want)
+			}
+			if got.Name != tt.want {
+				t.Errorf("matched %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+
+	if got := matchPricingRule([]models.PricingRule{urgent}, monday.Add(10*time.Hour), holidays); got != nil {
+		t.Errorf("matched %s outside its hours, want the base price", got.Name)
+	}
+}
@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/customerror"
+	"take-home-test/pkg/payment"
+	"time"
+)
+
+type splitPaymentUsecase usecase
+
+type SplitPaymentInterface interface {
+	SplitPayment(ctx context.Context, bookingID string, req models.SplitPaymentRequest) (*models.SplitPaymentResponse, error)
+	GetSplitPayment(ctx context.Context, bookingID string) (*models.SplitPaymentResponse, error)
+	CoverRemainder(ctx context.Context, bookingID string) (*models.PaymentTransactionResponse, error)
+	GetUserShares(ctx context.Context, userID string) ([]models.PaymentShareResponse, error)
+	PayShare(ctx context.Context, userID, shareID string) (*models.PaymentTransactionResponse, error)
+}
+
+// SplitPayment splits the payment of an unpaid booking evenly between its
+// owner and the invited emails; the owner's share takes what does not divide
+// evenly. The booking stays held until the deadline, by which every share
+// must be paid or the owner must cover the rest. Invited emails registered
+// with an account are notified.
+func (u *splitPaymentUsecase) SplitPayment(ctx context.Context, bookingID string, req models.SplitPaymentRequest) (*models.SplitPaymentResponse, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != constants.BOOKING_STATUS_PENDING || booking.SeriesID != nil || booking.OrderID != nil {
+		return nil, customerror.NewBadRequestError(constants.ErrSplitNotAllowed)
+	}
+
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentRecord.SplitDeadline != nil {
+		return nil, customerror.NewBadRequestError(constants.ErrAlreadySplit)
+	}
+	if paymentRecord.PaidAmount > 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrSplitNotAllowed)
+	}
+
+	owner, err := u.Options.Repository.User.FindByID(ctx, booking.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := splitEmails(req.Emails, owner.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every share must be worth something
+	count := len(emails) + 1
+	if paymentRecord.Amount < count {
+		return nil, customerror.NewBadRequestError(constants.ErrSplitNotAllowed)
+	}
+
+	now := time.Now()
+	deadline := now.Add(u.Options.Config.GetSplitPaymentTTL())
+	if deadline.After(booking.StartTime) {
+		deadline = booking.StartTime
+	}
+	if req.Deadline != nil {
+		deadline = *req.Deadline
+	}
+	if !deadline.After(now) || deadline.After(booking.StartTime) {
+		return nil, customerror.NewBadRequestError(constants.ErrInvalidDeadline)
+	}
+
+	share := paymentRecord.Amount / count
+	shares := []models.PaymentShare{{
+		PaymentID: paymentRecord.ID,
+		BookingID: booking.ID,
+		Email:     strings.ToLower(owner.Email),
+		Amount:    paymentRecord.Amount - share*(count-1),
+		Status:    constants.SHARE_STATUS_PENDING,
+	}}
+	for _, email := range emails {
+		shares = append(shares, models.PaymentShare{
+			PaymentID: paymentRecord.ID,
+			BookingID: booking.ID,
+			Email:     email,
+			Amount:    share,
+			Status:    constants.SHARE_STATUS_PENDING,
+		})
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, booking.FieldID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// A transaction the owner opened for the whole amount cannot be paid
+	// anymore
+	if err := (*paymentUsecase)(u).expireAttempts(ctx, paymentRecord); err != nil {
+		return nil, err
+	}
+
+	loc := u.Options.Config.GetVenueLocation()
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		split, err := tx.Payment.SplitPayment(ctx, paymentRecord.ID.String(), deadline)
+		if err != nil {
+			return err
+		}
+		if !split {
+			// Split or paid by a concurrent request
+			return customerror.NewBadRequestError(constants.ErrAlreadySplit)
+		}
+
+		held, err := tx.Booking.ExtendHold(ctx, bookingID, deadline)
+		if err != nil {
+			return err
+		}
+		if !held {
+			return customerror.NewBadRequestError(constants.ErrSplitNotAllowed)
+		}
+
+		if shares, err = tx.Share.CreateShares(ctx, shares); err != nil {
+			return err
+		}
+
+		for _, share := range shares[1:] {
+			user, err := tx.User.FindByEmail(ctx, share.Email)
+			if err != nil {
+				if _, ok := err.(customerror.NotFoundError); ok {
+					continue
+				}
+				return err
+			}
+
+			_, err = tx.Notification.CreateNotification(ctx, models.Notification{
+				UserID: user.ID,
+				Type:   constants.NOTIFICATION_SPLIT_INVITE,
+				Title:  "You are invited to split a booking",
+				Message: fmt.Sprintf("%s invited you to play on %s from %s to %s. Pay your share of %d before %s.",
+					owner.Name,
+					field.Name,
+					booking.StartTime.In(loc).Format("Mon 2 Jan 2006 15:04"),
+					booking.EndTime.In(loc).Format("15:04"),
+					share.Amount,
+					deadline.In(loc).Format("Mon 2 Jan 2006 15:04")),
+				BookingID: &booking.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetSplitPayment(ctx, bookingID)
+}
+
+// GetSplitPayment returns the split payment of the booking with the state of
+// each share.
+func (u *splitPaymentUsecase) GetSplitPayment(ctx context.Context, bookingID string) (*models.SplitPaymentResponse, error) {
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentRecord.SplitDeadline == nil {
+		return nil, customerror.NewBadRequestError(constants.ErrNotSplit)
+	}
+
+	shares, err := u.Options.Repository.Share.GetSharesByPaymentID(ctx, paymentRecord.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SplitPaymentResponse{
+		BookingID:  paymentRecord.BookingID,
+		PaymentID:  paymentRecord.ID,
+		Amount:     paymentRecord.Amount,
+		PaidAmount: paymentRecord.PaidAmount,
+		Remaining:  max(paymentRecord.Amount-paymentRecord.PaidAmount, 0),
+		Deadline:   *paymentRecord.SplitDeadline,
+		Shares:     make([]models.PaymentShareResponse, 0, len(shares)),
+	}
+	for _, share := range shares {
+		response.Shares = append(response.Shares, toPaymentShareResponse(share))
+	}
+
+	return response, nil
+}
+
+// CoverRemainder starts a transaction for the owner paying whatever the
+// shares have not paid yet. The shares' open transactions are expired so
+// nothing is paid twice; once it settles the shares left unpaid are covered.
+func (u *splitPaymentUsecase) CoverRemainder(ctx context.Context, bookingID string) (*models.PaymentTransactionResponse, error) {
+	booking, paymentRecord, err := u.openSplit(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := paymentRecord.Amount - paymentRecord.PaidAmount
+	if amount <= 0 {
+		return nil, customerror.NewBadRequestError(constants.ErrPaymentAlreadyProcessed)
+	}
+
+	owner, err := u.Options.Repository.User.FindByID(ctx, booking.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, booking.FieldID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := (*paymentUsecase)(u).expireAttempts(ctx, paymentRecord); err != nil {
+		return nil, err
+	}
+
+	attempt, err := (*paymentUsecase)(u).createAttempt(ctx, paymentRecord, amount, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*paymentUsecase)(u).startTransaction(ctx, paymentRecord, attempt, owner, []payment.Item{{
+		ID:    paymentRecord.ID.String(),
+		Name:  fmt.Sprintf("Unpaid shares %s - %s", field.Name, booking.StartTime.Format("02 Jan 2006 15:04")),
+		Price: int64(amount),
+	}})
+}
+
+// GetUserShares lists the shares the user was invited to pay, matched by
+// the user's email, latest first.
+func (u *splitPaymentUsecase) GetUserShares(ctx context.Context, userID string) ([]models.PaymentShareResponse, error) {
+	user, err := u.Options.Repository.User.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := u.Options.Repository.Share.GetSharesByEmail(ctx, strings.ToLower(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.PaymentShareResponse, 0, len(shares))
+	for _, share := range shares {
+		responses = append(responses, toPaymentShareResponse(share))
+	}
+	return responses, nil
+}
+
+// PayShare starts a transaction for the user paying their share. A
+// transaction opened for the share before is expired first.
+func (u *splitPaymentUsecase) PayShare(ctx context.Context, userID, shareID string) (*models.PaymentTransactionResponse, error) {
+	user, err := u.Options.Repository.User.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := u.Options.Repository.Share.GetShareByID(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share.Email != strings.ToLower(user.Email) {
+		return nil, customerror.NewNotFoundErrorf(constants.ErrShareNotFound, shareID)
+	}
+	if share.Status != constants.SHARE_STATUS_PENDING {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrShareClosed, share.Status)
+	}
+
+	booking, paymentRecord, err := u.openSplit(ctx, share.BookingID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, booking.FieldID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := u.Options.Repository.Payment.GetAttemptsByPaymentID(ctx, paymentRecord.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range attempts {
+		if attempt.ShareID != nil && *attempt.ShareID == share.ID && attempt.Status == constants.PAYMENT_STATUS_PENDING {
+			if err := (*paymentUsecase)(u).expireAttempt(ctx, attempt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	attempt, err := (*paymentUsecase)(u).createAttempt(ctx, paymentRecord, share.Amount, &share.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*paymentUsecase)(u).startTransaction(ctx, paymentRecord, attempt, user, []payment.Item{{
+		ID:    share.ID.String(),
+		Name:  fmt.Sprintf("Share of booking %s - %s", field.Name, booking.StartTime.Format("02 Jan 2006 15:04")),
+		Price: int64(share.Amount),
+	}})
+}
+
+// openSplit returns the booking and its split payment while the shares can
+// still be paid: the booking is pending and the deadline has not passed.
+func (u *splitPaymentUsecase) openSplit(ctx context.Context, bookingID string) (models.Booking, models.Payment, error) {
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return booking, models.Payment{}, err
+	}
+
+	paymentRecord, err := u.Options.Repository.Payment.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return booking, paymentRecord, err
+	}
+	if paymentRecord.SplitDeadline == nil {
+		return booking, paymentRecord, customerror.NewBadRequestError(constants.ErrNotSplit)
+	}
+
+	switch {
+	case booking.Status == constants.BOOKING_STATUS_CANCELED:
+		return booking, paymentRecord, customerror.NewBadRequestError(constants.ErrBookingCanceled)
+	case booking.Status != constants.BOOKING_STATUS_PENDING:
+		return booking, paymentRecord, customerror.NewBadRequestError(constants.ErrPaymentAlreadyProcessed)
+	case !paymentRecord.SplitDeadline.After(time.Now()):
+		return booking, paymentRecord, customerror.NewBadRequestError(constants.ErrDeadlinePassed)
+	}
+
+	return booking, paymentRecord, nil
+}
+
+// settleShares marks the shares whose attempts settled as paid. Once the
+// whole payment is paid, the owner covered the shares still unpaid.
+func (u *splitPaymentUsecase) settleShares(ctx context.Context, paymentRecord models.Payment, attempts []models.PaymentAttempt, status string) error {
+	for _, attempt := range attempts {
+		if attempt.ShareID == nil || attempt.Status != constants.PAYMENT_STATUS_SUCCESS {
+			continue
+		}
+		if err := u.Options.Repository.Share.MarkSharePaid(ctx, attempt.ShareID.String()); err != nil {
+			return err
+		}
+	}
+
+	if status != constants.PAYMENT_STATUS_SUCCESS {
+		return nil
+	}
+	return u.Options.Repository.Share.CloseShares(ctx, paymentRecord.ID.String(), constants.SHARE_STATUS_COVERED)
+}
+
+// splitEmails normalizes the invited emails, which must be valid, distinct
+// and other than the owner's.
+func splitEmails(emails []string, ownerEmail string) ([]string, error) {
+	invalid := customerror.NewBadRequestErrorf(constants.ErrInvalidSplitEmails, constants.MAX_SPLIT_SHARES-1)
+	if len(emails) == 0 || len(emails) >= constants.MAX_SPLIT_SHARES {
+		return nil, invalid
+	}
+
+	seen := map[string]bool{strings.ToLower(ownerEmail): true}
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email || seen[email] {
+			return nil, invalid
+		}
+		seen[email] = true
+		normalized = append(normalized, email)
+	}
+	return normalized, nil
+}
+
+func toPaymentShareResponse(share models.PaymentShare) models.PaymentShareResponse {
+	return models.PaymentShareResponse{
+		ID:        share.ID,
+		BookingID: share.BookingID,
+		Email:     share.Email,
+		Amount:    share.Amount,
+		Status:    share.Status,
+		PaidAt:    share.PaidAt,
+		CreatedAt: share.CreatedAt,
+	}
+}
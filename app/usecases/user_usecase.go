@@ -2,13 +2,17 @@ package usecase
 
 import (
 	"context"
+	"take-home-test/app/constants"
 	"take-home-test/app/models"
+	"take-home-test/pkg/customerror"
 )
 
 type userUsecase usecase
 
 type UserInterface interface {
 	GetUserByID(ctx context.Context, id string) (*models.UserResponse, error)
+	UpdateUserRole(ctx context.Context, actorID, id string, role string) (*models.UserResponse, error)
+	GetUserCredits(ctx context.Context, userID string) (*models.CreditBalanceResponse, error)
 }
 
 func (u *userUsecase) GetUserByID(ctx context.Context, id string) (*models.UserResponse, error) {
@@ -26,4 +30,70 @@ func (u *userUsecase) GetUserByID(ctx context.Context, id string) (*models.UserR
 	}
 
 	return userResponse, nil
-}
\ No newline at end of file
+}
+
+// UpdateUserRole promotes or demotes a user. Existing sessions of the user are
+// revoked so the role claim in their access tokens cannot outlive the change.
+func (u *userUsecase) UpdateUserRole(ctx context.Context, actorID, id string, role string) (*models.UserResponse, error) {
+	if actorID == id {
+		return nil, customerror.NewBadRequestError(constants.ErrCannotChangeOwnRole)
+	}
+
+	user, err := u.Options.Repository.User.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == constants.ROLE_ADMIN && role != constants.ROLE_ADMIN {
+		admins, err := u.Options.Repository.User.CountUsersByRole(ctx, constants.ROLE_ADMIN)
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, customerror.NewBadRequestError(constants.ErrLastAdmin)
+		}
+	}
+
+	if user.Role != role {
+		if err := u.Options.Repository.User.UpdateUserRole(ctx, id, role); err != nil {
+			return nil, err
+		}
+
+		if err := u.Options.Repository.Session.RevokeUserSessions(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	userResponse := &models.UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      role,
+		CreatedAt: user.CreatedAt,
+	}
+
+	return userResponse, nil
+}
+
+// GetUserCredits returns the user's credit balance and the credits making it
+// up, latest first.
+func (u *userUsecase) GetUserCredits(ctx context.Context, userID string) (*models.CreditBalanceResponse, error) {
+	credits, err := u.Options.Repository.Credit.GetUserCredits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.CreditBalanceResponse{Credits: make([]models.CreditResponse, 0, len(credits))}
+	for _, credit := range credits {
+		response.Balance += credit.Amount
+		response.Credits = append(response.Credits, models.CreditResponse{
+			ID:        credit.ID,
+			BookingID: credit.BookingID,
+			Amount:    credit.Amount,
+			Reason:    credit.Reason,
+			CreatedAt: credit.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
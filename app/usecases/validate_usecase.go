@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"take-home-test/app/constants"
+	"take-home-test/pkg/customerror"
 	"time"
 
 )
@@ -103,44 +104,60 @@ func (v *validateUsecase) IsAdminUser(ctx context.Context, userID string) error
 func (v *validateUsecase) IsValidBookingTime(ctx context.Context, fieldID string, startTime, endTime string) error {
 	// Check if field exists
 	if _, err := v.Options.Repository.Field.GetFieldByID(ctx, fieldID); err != nil {
-		return fmt.Errorf(constants.ErrFieldNotFound, fieldID)
+		return customerror.NewNotFoundErrorf(constants.ErrFieldNotFound, fieldID)
 	}
 
 	// Parse times using constants
 	start, err := parseTime(startTime)
 	if err != nil {
-		return fmt.Errorf("invalid start time format: %v", err)
+		return customerror.NewBadRequestErrorf("invalid start time format: %v", err)
 	}
 
 	end, err := parseTime(endTime)
 	if err != nil {
-		return fmt.Errorf("invalid end time format: %v", err)
+		return customerror.NewBadRequestErrorf("invalid end time format: %v", err)
 	}
 
+	return v.isValidSlot(ctx, fieldID, start, end, "")
+}
+
+// isValidSlot applies the booking time rules to [start, end) on the field.
+// excludeBookingID, when set, is left out of the overlap check so a booking
+// can move to a slot overlapping its current one.
+func (v *validateUsecase) isValidSlot(ctx context.Context, fieldID string, start, end time.Time, excludeBookingID string) error {
 	// Check if end time is after start time
 	if !end.After(start) {
-		return fmt.Errorf(constants.ErrInvalidTimeRange)
+		return customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	}
+
+	// Check opening hours, maintenance blackouts and holidays
+	closures, err := loadFieldClosures(ctx, v.Options.Repository, v.Options.Config.GetVenueLocation(), fieldID, start, end)
+	if err != nil {
+		return err
+	}
+	if err := closures.check(start, end); err != nil {
+		return err
 	}
 
 	// Check for time overlap
-	hasOverlap, err := v.Options.Repository.Booking.CheckTimeOverlap(ctx, fieldID, start, end)
+	hasOverlap, err := v.Options.Repository.Booking.CheckTimeOverlap(ctx, fieldID, start, end, excludeBookingID)
 	if err != nil {
-		return fmt.Errorf("failed to check booking availability: %v", err)
+		return customerror.NewInternalServiceErrorf("failed to check booking availability: %v", err)
 	}
 
 	if hasOverlap {
-		return fmt.Errorf(constants.ErrTimeSlotOverlap)
+		return customerror.NewConflictError(constants.ErrTimeSlotOverlap)
 	}
 
 	// Check if booking is in the past
 	if start.Before(time.Now()) {
-		return fmt.Errorf(constants.ErrBookingInPast)
+		return customerror.NewBadRequestError(constants.ErrBookingInPast)
 	}
 
 	// Check minimum duration
 	minDuration := time.Hour
 	if end.Sub(start) < minDuration {
-		return fmt.Errorf(constants.ErrMinimumDuration)
+		return customerror.NewBadRequestError(constants.ErrMinimumDuration)
 	}
 
 	return nil
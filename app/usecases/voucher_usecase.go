@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"take-home-test/app/constants"
+	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/customerror"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type voucherUsecase usecase
+
+var voucherCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type VoucherInterface interface {
+	GetVouchers(ctx context.Context) ([]models.VoucherResponse, error)
+	GetVoucherByID(ctx context.Context, id string) (*models.VoucherResponse, error)
+	CreateVoucher(ctx context.Context, req models.VoucherRequest) (*models.VoucherResponse, error)
+	UpdateVoucher(ctx context.Context, id string, req models.VoucherRequest) (*models.VoucherResponse, error)
+}
+
+func (u *voucherUsecase) GetVouchers(ctx context.Context) ([]models.VoucherResponse, error) {
+	vouchers, err := u.Options.Repository.Voucher.GetVouchers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		responses = append(responses, toVoucherResponse(voucher))
+	}
+	return responses, nil
+}
+
+func (u *voucherUsecase) GetVoucherByID(ctx context.Context, id string) (*models.VoucherResponse, error) {
+	voucher, err := u.Options.Repository.Voucher.GetVoucherByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toVoucherResponse(voucher)
+	return &response, nil
+}
+
+func (u *voucherUsecase) CreateVoucher(ctx context.Context, req models.VoucherRequest) (*models.VoucherResponse, error) {
+	voucher, err := u.newVoucher(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err = u.Options.Repository.Voucher.CreateVoucher(ctx, voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toVoucherResponse(voucher)
+	return &response, nil
+}
+
+// UpdateVoucher replaces the settings of a voucher. Discounts already given
+// are not changed; lowering usage_limit below used_count is rejected.
+func (u *voucherUsecase) UpdateVoucher(ctx context.Context, id string, req models.VoucherRequest) (*models.VoucherResponse, error) {
+	existing, err := u.Options.Repository.Voucher.GetVoucherByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err := u.newVoucher(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.UsageLimit != nil && *voucher.UsageLimit < existing.UsedCount {
+		return nil, customerror.NewBadRequestErrorf(constants.ErrUsageLimitBelowUsed, existing.UsedCount)
+	}
+	voucher.ID = existing.ID
+	voucher.UsedCount = existing.UsedCount
+	voucher.CreatedAt = existing.CreatedAt
+
+	voucher, err = u.Options.Repository.Voucher.UpdateVoucher(ctx, voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toVoucherResponse(voucher)
+	return &response, nil
+}
+
+func (u *voucherUsecase) newVoucher(ctx context.Context, req models.VoucherRequest) (models.Voucher, error) {
+	code := normalizeVoucherCode(req.Code)
+	if !voucherCodePattern.MatchString(code) {
+		return models.Voucher{}, customerror.NewBadRequestError(constants.ErrInvalidVoucherCode)
+	}
+
+	discountType := strings.ToLower(strings.TrimSpace(req.DiscountType))
+	if discountType != constants.DISCOUNT_TYPE_PERCENTAGE && discountType != constants.DISCOUNT_TYPE_FIXED {
+		return models.Voucher{}, customerror.NewBadRequestErrorf(constants.ErrInvalidDiscountType, req.DiscountType)
+	}
+
+	if req.DiscountValue <= 0 || (discountType == constants.DISCOUNT_TYPE_PERCENTAGE && req.DiscountValue > 100) {
+		return models.Voucher{}, customerror.NewBadRequestError(constants.ErrInvalidDiscountValue)
+	}
+
+	for _, limit := range []*int{req.MaxDiscount, req.UsageLimit, req.PerUserLimit, &req.MinAmount} {
+		if limit != nil && *limit < 0 {
+			return models.Voucher{}, customerror.NewBadRequestError(constants.ErrInvalidVoucherLimit)
+		}
+	}
+
+	if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom) {
+		return models.Voucher{}, customerror.NewBadRequestError(constants.ErrInvalidVoucherValidity)
+	}
+
+	fieldIDs := make([]uuid.UUID, 0, len(req.FieldIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, fieldID := range req.FieldIDs {
+		if seen[fieldID] {
+			continue
+		}
+		seen[fieldID] = true
+
+		if _, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID.String()); err != nil {
+			return models.Voucher{}, err
+		}
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return models.Voucher{
+		Code:          code,
+		Description:   strings.TrimSpace(req.Description),
+		DiscountType:  discountType,
+		DiscountValue: req.DiscountValue,
+		MaxDiscount:   req.MaxDiscount,
+		MinAmount:     req.MinAmount,
+		UsageLimit:    req.UsageLimit,
+		PerUserLimit:  req.PerUserLimit,
+		ValidFrom:     req.ValidFrom,
+		ValidUntil:    req.ValidUntil,
+		Active:        active,
+		FieldIDs:      fieldIDs,
+	}, nil
+}
+
+// Voucher codes are matched case-insensitively and stored in upper case.
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// applyVoucher looks up a voucher code and works out its discount on amount
+// for a booking of fieldID. Usage limits are checked when it is redeemed.
+func (u *voucherUsecase) applyVoucher(ctx context.Context, code string, fieldID uuid.UUID, amount int) (*models.Voucher, int, error) {
+	code = normalizeVoucherCode(code)
+
+	voucher, err := u.Options.Repository.Voucher.GetVoucherByCode(ctx, code)
+	if err != nil {
+		if _, ok := err.(customerror.NotFoundError); ok {
+			return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherInvalid, code)
+		}
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	switch {
+	case !voucher.Active:
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherInactive, code)
+	case voucher.ValidFrom != nil && now.Before(*voucher.ValidFrom):
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherNotStarted, code)
+	case voucher.ValidUntil != nil && !now.Before(*voucher.ValidUntil):
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherExpired, code)
+	case len(voucher.FieldIDs) > 0 && !slices.Contains(voucher.FieldIDs, fieldID):
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherFieldNotAllowed, code)
+	case amount < voucher.MinAmount:
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherMinAmount, code, voucher.MinAmount)
+	case voucher.UsageLimit != nil && voucher.UsedCount >= *voucher.UsageLimit:
+		return nil, 0, customerror.NewBadRequestErrorf(constants.ErrVoucherUsedUp, code)
+	}
+
+	discount := voucher.DiscountValue
+	if voucher.DiscountType == constants.DISCOUNT_TYPE_PERCENTAGE {
+		discount = amount * voucher.DiscountValue / 100
+		if voucher.MaxDiscount != nil {
+			discount = min(discount, *voucher.MaxDiscount)
+		}
+	}
+
+	return &voucher, min(discount, amount), nil
+}
+
+// redeemVoucher records the use of the voucher for a booking within the
+// booking's transaction. Counting the use locks the voucher row, so the
+// usage and per-user limits hold under concurrent bookings.
+func redeemVoucher(ctx context.Context, tx *repositories.Main, voucher *models.Voucher, booking models.Booking, discount int) error {
+	ok, err := tx.Voucher.IncrementUsage(ctx, voucher.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return customerror.NewBadRequestErrorf(constants.ErrVoucherUsedUp, voucher.Code)
+	}
+
+	if voucher.PerUserLimit != nil {
+		used, err := tx.Voucher.CountUserRedemptions(ctx, voucher.ID, booking.UserID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*voucher.PerUserLimit) {
+			return customerror.NewBadRequestErrorf(constants.ErrVoucherUserLimit, voucher.Code)
+		}
+	}
+
+	_, err = tx.Voucher.CreateRedemption(ctx, models.VoucherRedemption{
+		VoucherID:      voucher.ID,
+		UserID:         booking.UserID,
+		BookingID:      booking.ID,
+		DiscountAmount: discount,
+	})
+	return err
+}
+
+func toVoucherResponse(voucher models.Voucher) models.VoucherResponse {
+	fieldIDs := voucher.FieldIDs
+	if fieldIDs == nil {
+		fieldIDs = []uuid.UUID{}
+	}
+
+	return models.VoucherResponse{
+		ID:            voucher.ID,
+		Code:          voucher.Code,
+		Description:   voucher.Description,
+		DiscountType:  voucher.DiscountType,
+		DiscountValue: voucher.DiscountValue,
+		MaxDiscount:   voucher.MaxDiscount,
+		MinAmount:     voucher.MinAmount,
+		UsageLimit:    voucher.UsageLimit,
+		PerUserLimit:  voucher.PerUserLimit,
+		UsedCount:     voucher.UsedCount,
+		ValidFrom:     voucher.ValidFrom,
+		ValidUntil:    voucher.ValidUntil,
+		Active:        voucher.Active,
+		FieldIDs:      fieldIDs,
+		CreatedAt:     voucher.CreatedAt,
+	}
+}
@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"take-home-test/app/constants"
+	"take-home-test/app/helpers"
+	"take-home-test/app/models"
+	"take-home-test/app/repositories"
+	"take-home-test/pkg/customerror"
+	"time"
+)
+
+type waitlistUsecase usecase
+
+type WaitlistInterface interface {
+	JoinWaitlist(ctx context.Context, userID string, req models.WaitlistRequest) (*models.WaitlistEntryResponse, error)
+	GetUserWaitlist(ctx context.Context, userID string) ([]models.WaitlistEntryResponse, error)
+	LeaveWaitlist(ctx context.Context, userID, id string) (*models.WaitlistEntryResponse, error)
+	ConfirmOffer(ctx context.Context, userID, id string) (*models.BookingResponse, error)
+	ExpireStaleEntries(ctx context.Context) (int64, error)
+}
+
+// errEntryTaken rolls back an offer whose entry left the waitlist meanwhile.
+var errEntryTaken = errors.New("waitlist entry is no longer waiting")
+
+// JoinWaitlist puts the user on the waitlist of a slot that is open but
+// already booked. A slot that is still free must be booked directly.
+func (u *waitlistUsecase) JoinWaitlist(ctx context.Context, userID string, req models.WaitlistRequest) (*models.WaitlistEntryResponse, error) {
+	switch {
+	case !req.EndTime.After(req.StartTime):
+		return nil, customerror.NewBadRequestError(constants.ErrInvalidTimeRange)
+	case req.StartTime.Before(time.Now()):
+		return nil, customerror.NewBadRequestError(constants.ErrBookingInPast)
+	case req.EndTime.Sub(req.StartTime) < time.Hour:
+		return nil, customerror.NewBadRequestError(constants.ErrMinimumDuration)
+	}
+
+	fieldID := req.FieldID.String()
+	if _, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID); err != nil {
+		return nil, err
+	}
+
+	closures, err := loadFieldClosures(ctx, u.Options.Repository, u.Options.Config.GetVenueLocation(), fieldID, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := closures.check(req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+
+	hasOverlap, err := u.Options.Repository.Booking.CheckTimeOverlap(ctx, fieldID, req.StartTime, req.EndTime, "")
+	if err != nil {
+		return nil, err
+	}
+	if !hasOverlap {
+		return nil, customerror.NewBadRequestError(constants.ErrSlotAvailable)
+	}
+
+	entry, err := u.Options.Repository.Waitlist.CreateEntry(ctx, models.WaitlistEntry{
+		UserID:    helpers.ParseUUID(userID),
+		FieldID:   req.FieldID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Status:    constants.WAITLIST_STATUS_WAITING,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toWaitlistEntryResponse(entry)
+	return &response, nil
+}
+
+func (u *waitlistUsecase) GetUserWaitlist(ctx context.Context, userID string) ([]models.WaitlistEntryResponse, error) {
+	entries, err := u.Options.Repository.Waitlist.GetUserEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.WaitlistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, toWaitlistEntryResponse(entry))
+	}
+	return responses, nil
+}
+
+// LeaveWaitlist takes the user off the waitlist. An open offer is declined,
+// which passes the slot on to the next user waiting for it.
+func (u *waitlistUsecase) LeaveWaitlist(ctx context.Context, userID, id string) (*models.WaitlistEntryResponse, error) {
+	entry, err := u.userEntry(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.Status {
+	case constants.WAITLIST_STATUS_WAITING:
+		left, err := u.Options.Repository.Waitlist.UpdateEntry(ctx, id, []string{constants.WAITLIST_STATUS_WAITING}, map[string]interface{}{
+			"status": constants.WAITLIST_STATUS_CANCELED,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !left {
+			// Offered the slot meanwhile
+			return u.LeaveWaitlist(ctx, userID, id)
+		}
+
+	case constants.WAITLIST_STATUS_OFFERED:
+		booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, entry.BookingID.String())
+		if err != nil {
+			return nil, err
+		}
+		_, err = (*bookingUsecase)(u).cancel(ctx, booking, 0, models.Actor{
+			Source: constants.STATUS_SOURCE_USER,
+			ID:     &entry.UserID,
+		}, "Waitlist offer declined")
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, customerror.NewBadRequestErrorf(constants.ErrWaitlistEntryClosed, entry.Status)
+	}
+
+	entry, err = u.Options.Repository.Waitlist.GetEntryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toWaitlistEntryResponse(entry)
+	return &response, nil
+}
+
+// ConfirmOffer turns the slot held for the user into a regular booking at
+// the current price, waiting for its payment like any new booking.
+func (u *waitlistUsecase) ConfirmOffer(ctx context.Context, userID, id string) (*models.BookingResponse, error) {
+	entry, err := u.userEntry(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != constants.WAITLIST_STATUS_OFFERED || entry.BookingID == nil {
+		return nil, customerror.NewBadRequestError(constants.ErrNoWaitlistOffer)
+	}
+
+	bookingID := entry.BookingID.String()
+	booking, err := u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != constants.BOOKING_STATUS_HELD {
+		return nil, customerror.NewBadRequestError(constants.ErrNoWaitlistOffer)
+	}
+	if booking.HoldExpiresAt != nil && !booking.HoldExpiresAt.After(time.Now()) {
+		return nil, customerror.NewBadRequestError(constants.ErrOfferExpired)
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, booking.FieldID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := (*pricingUsecase)(u).quote(ctx, field, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	holdExpiresAt := time.Now().Add(u.Options.Config.GetBookingHoldTTL())
+	change := models.StatusChange{
+		To:     constants.BOOKING_STATUS_PENDING,
+		Actor:  models.Actor{Source: constants.STATUS_SOURCE_USER, ID: &entry.UserID},
+		Reason: "Waitlist offer confirmed",
+	}
+	payment := models.Payment{
+		BookingID:      booking.ID,
+		Amount:         quote.Amount,
+		OriginalAmount: quote.Amount,
+		DepositAmount:  depositAmount(field, quote.Amount),
+		Status:         constants.PAYMENT_STATUS_PENDING,
+	}
+
+	// Nothing to pay: settled right away
+	if quote.Amount == 0 {
+		now := time.Now()
+		change.To = constants.BOOKING_STATUS_PAID
+		payment.Status = constants.PAYMENT_STATUS_SUCCESS
+		payment.PaidAt = &now
+	}
+
+	err = u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+		var hold *time.Time
+		if change.To == constants.BOOKING_STATUS_PENDING {
+			hold = &holdExpiresAt
+		}
+		if err := tx.Booking.ConfirmHold(ctx, bookingID, change, hold, quote.Amount); err != nil {
+			return err
+		}
+
+		if _, err := tx.Payment.CreatePayment(ctx, payment); err != nil {
+			return err
+		}
+
+		_, err := tx.Waitlist.UpdateEntry(ctx, id, []string{constants.WAITLIST_STATUS_OFFERED}, map[string]interface{}{
+			"status": constants.WAITLIST_STATUS_BOOKED,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err = u.Options.Repository.Booking.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toBookingResponse(booking)
+	return &response, nil
+}
+
+// ExpireStaleEntries closes the waiting entries whose slot has started
+// without being freed. It is run periodically by the scheduler.
+func (u *waitlistUsecase) ExpireStaleEntries(ctx context.Context) (int64, error) {
+	return u.Options.Repository.Waitlist.ExpireStaleEntries(ctx, time.Now())
+}
+
+// userEntry returns the user's waitlist entry; another user's entry is
+// reported as not found.
+func (u *waitlistUsecase) userEntry(ctx context.Context, userID, id string) (models.WaitlistEntry, error) {
+	entry, err := u.Options.Repository.Waitlist.GetEntryByID(ctx, id)
+	if err != nil {
+		return entry, err
+	}
+	if entry.UserID.String() != userID {
+		return entry, customerror.NewNotFoundErrorf(constants.ErrWaitlistEntryNotFound, id)
+	}
+	return entry, nil
+}
+
+// releaseSlot is called once a booking has been canceled. When the booking
+// was a waitlist offer its entry is closed with entryStatus, then the freed
+// slot is offered to the users waiting for it. The cancellation itself
+// already succeeded, so failures are only logged.
+func (u *waitlistUsecase) releaseSlot(ctx context.Context, booking models.Booking, entryStatus string) {
+	if booking.Status == constants.BOOKING_STATUS_HELD {
+		entry, err := u.Options.Repository.Waitlist.GetEntryByBookingID(ctx, booking.ID.String())
+		if err == nil {
+			_, err = u.Options.Repository.Waitlist.UpdateEntry(ctx, entry.ID.String(), []string{constants.WAITLIST_STATUS_OFFERED}, map[string]interface{}{
+				"status": entryStatus,
+			})
+		}
+		if err != nil {
+			log.Printf("closing waitlist offer of booking %s: %v", booking.ID, err)
+		}
+	}
+
+	if err := u.offerSlot(ctx, booking); err != nil {
+		log.Printf("offering slot of booking %s to the waitlist: %v", booking.ID, err)
+	}
+}
+
+// offerSlot holds the freed slot of booking for the users waiting for an
+// overlapping slot, oldest entry first. Every entry whose whole slot is now
+// free and open gets a held booking until WAITLIST_OFFER_TTL has passed, or
+// until the slot starts, and a notification.
+func (u *waitlistUsecase) offerSlot(ctx context.Context, booking models.Booking) error {
+	now := time.Now()
+	fieldID := booking.FieldID.String()
+	entries, err := u.Options.Repository.Waitlist.GetWaitingEntries(ctx, fieldID, booking.StartTime, booking.EndTime, now)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	field, err := u.Options.Repository.Field.GetFieldByID(ctx, fieldID)
+	if err != nil {
+		return err
+	}
+
+	from, to := entries[0].StartTime, entries[0].EndTime
+	for _, entry := range entries {
+		from = minTime(from, entry.StartTime)
+		to = maxTime(to, entry.EndTime)
+	}
+
+	loc := u.Options.Config.GetVenueLocation()
+	closures, err := loadFieldClosures(ctx, u.Options.Repository, loc, fieldID, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if closures.check(entry.StartTime, entry.EndTime) != nil {
+			continue
+		}
+
+		offerExpiresAt := minTime(now.Add(u.Options.Config.GetWaitlistOfferTTL()), entry.StartTime)
+		err := u.Options.Repository.Transaction(ctx, func(tx *repositories.Main) error {
+			// The bookings_no_overlap constraint rejects an entry whose slot
+			// is still partly taken.
+			held, err := tx.Booking.CreateBooking(ctx, models.Booking{
+				UserID:        entry.UserID,
+				FieldID:       entry.FieldID,
+				StartTime:     entry.StartTime,
+				EndTime:       entry.EndTime,
+				Status:        constants.BOOKING_STATUS_HELD,
+				HoldExpiresAt: &offerExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			offered, err := tx.Waitlist.UpdateEntry(ctx, entry.ID.String(), []string{constants.WAITLIST_STATUS_WAITING}, map[string]interface{}{
+				"status":           constants.WAITLIST_STATUS_OFFERED,
+				"booking_id":       held.ID,
+				"offer_expires_at": offerExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+			if !offered {
+				return errEntryTaken
+			}
+
+			_, err = tx.Notification.CreateNotification(ctx, models.Notification{
+				UserID: entry.UserID,
+				Type:   constants.NOTIFICATION_WAITLIST_OFFER,
+				Title:  "A slot you are waiting for is available",
+				Message: fmt.Sprintf("%s from %s to %s is held for you until %s. Confirm it to book the slot.",
+					field.Name,
+					entry.StartTime.In(loc).Format("Mon 2 Jan 2006 15:04"),
+					entry.EndTime.In(loc).Format("15:04"),
+					offerExpiresAt.In(loc).Format("15:04")),
+				BookingID: &held.ID,
+			})
+			return err
+		})
+		if err != nil {
+			if _, ok := err.(customerror.ConflictError); ok || errors.Is(err, errEntryTaken) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func toWaitlistEntryResponse(entry models.WaitlistEntry) models.WaitlistEntryResponse {
+	response := models.WaitlistEntryResponse{
+		ID:        entry.ID,
+		FieldID:   entry.FieldID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+		Status:    entry.Status,
+		BookingID: entry.BookingID,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	// The offer only matters while it is open
+	if entry.Status == constants.WAITLIST_STATUS_OFFERED {
+		response.OfferExpiresAt = entry.OfferExpiresAt
+	}
+
+	return response
+}
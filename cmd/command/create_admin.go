@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	application "take-home-test/app"
+	"take-home-test/app/models"
+)
+
+var cmdCreateAdmin = &cobra.Command{
+	Use:   "create-admin",
+	Short: "Create an admin account or promote an existing user",
+	Long:  `Bootstrap an admin account. Public registration always creates regular users, so the first admin has to be created from the command line.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		email, _ := cmd.Flags().GetString("email")
+		password, _ := cmd.Flags().GetString("password")
+
+		req := models.RegisterRequest{
+			Name:     name,
+			Email:    email,
+			Password: password,
+		}
+		if req.Email == "" {
+			log.Fatalf("Invalid admin account: --email is required")
+		}
+
+		app := application.New()
+		if err := app.Init(); err != nil {
+			log.Fatalf("Error in initializing the application: %+v", err)
+		}
+		defer app.Close()
+
+		user, err := app.UseCases().Auth.CreateAdmin(context.Background(), req)
+		if err != nil {
+			app.Close()
+			log.Fatalf("Error in creating the admin account: %+v", err)
+		}
+
+		log.Printf("admin account ready: %s <%s> (%s)", user.Name, user.Email, user.ID)
+	},
+}
+
+func init() {
+	cmdCreateAdmin.Flags().String("name", "Admin", "admin display name")
+	cmdCreateAdmin.Flags().String("email", "", "admin email")
+	cmdCreateAdmin.Flags().String("password", "", "admin password (ignored when promoting an existing user)")
+	_ = cmdCreateAdmin.MarkFlagRequired("email")
+
+	cmdRoot.AddCommand(cmdCreateAdmin)
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	application "take-home-test/app"
+	"take-home-test/pkg/migration"
+)
+
+var cmdMigrate = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+	Long:  `Apply, revert and inspect the versioned SQL migrations in the schema configured by DB_POSTGRES_SCHEMA`,
+}
+
+var cmdMigrateUp = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		withMigrator(func(ctx context.Context, migrator *migration.Migrator) error {
+			applied, err := migrator.Up(ctx)
+			for _, mig := range applied {
+				log.Printf("applied %06d_%s", mig.Version, mig.Name)
+			}
+			if err == nil && len(applied) == 0 {
+				log.Printf("no pending migrations")
+			}
+			return err
+		})
+	},
+}
+
+var cmdMigrateDown = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the latest applied migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		if steps < 1 {
+			log.Fatalf("--steps must be at least 1")
+		}
+
+		withMigrator(func(ctx context.Context, migrator *migration.Migrator) error {
+			reverted, err := migrator.Down(ctx, steps)
+			for _, mig := range reverted {
+				log.Printf("reverted %06d_%s", mig.Version, mig.Name)
+			}
+			if err == nil && len(reverted) == 0 {
+				log.Printf("no applied migrations to revert")
+			}
+			return err
+		})
+	},
+}
+
+var cmdMigrateStatus = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		withMigrator(func(ctx context.Context, migrator *migration.Migrator) error {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+			for _, s := range statuses {
+				state, appliedAt := "pending", "-"
+				if s.Applied {
+					state = "applied"
+					appliedAt = s.AppliedAt.Format(time.RFC3339)
+				}
+				if s.Modified {
+					state = "modified"
+				}
+				if s.Missing {
+					state = "missing"
+				}
+				fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+			}
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	cmdMigrateDown.Flags().Int("steps", 1, "number of migrations to revert")
+
+	cmdMigrate.AddCommand(cmdMigrateUp, cmdMigrateDown, cmdMigrateStatus)
+	cmdRoot.AddCommand(cmdMigrate)
+}
+
+func withMigrator(fn func(ctx context.Context, migrator *migration.Migrator) error) {
+	app := application.New()
+	if err := app.InitDatabase(); err != nil {
+		log.Fatalf("Error in initializing the database: %+v", err)
+	}
+	defer app.Close()
+
+	migrator, err := app.Migrator()
+	if err != nil {
+		log.Fatalf("Error in loading migrations: %+v", err)
+	}
+
+	if err := fn(context.Background(), migrator); err != nil {
+		app.Close()
+		log.Fatalf("Error in running migrations: %+v", err)
+	}
+}
@@ -38,6 +38,7 @@ func init() {
 	// Default yang aman jika tidak ada nilai
 	viper.SetDefault("APP_PORT", "3005")
 	viper.SetDefault("APP_HOST", "http://localhost:3005")
+	viper.SetDefault("REFUND_PARTIAL_PERCENT", 50)
 
 	// Logging ringkas agar mudah debugging CI (tidak menampilkan password)
 	log.Printf("config: APP_HOST=%s APP_PORT=%s DB_USER=%s DB_HOST=%s DB_NAME=%s",
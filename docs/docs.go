@@ -76,9 +76,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/logout": {
             "post": {
-                "description": "Register a new user account",
+                "description": "Revoke the current session and its access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,21 +88,55 @@ const docTemplate = `{
                 "tags": [
                     "Authentication"
                 ],
-                "summary": "Register new user",
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token. The refresh token is rotated and can only be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "description": "Register request",
+                        "description": "Refresh token request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.RegisterRequest"
+                            "$ref": "#/definitions/take-home-test_app_models.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -112,7 +146,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.UserResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.TokenResponse"
                                         }
                                     }
                                 }
@@ -125,8 +159,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
@@ -134,14 +168,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new field booking. **AUTHENTICATED USERS ONLY** - Users can only book for themselves. System checks for time slot availability and prevents overlapping bookings.",
+                "description": "Register a new user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,17 +178,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookings"
+                    "Authentication"
                 ],
-                "summary": "Create new booking",
+                "summary": "Register new user",
                 "parameters": [
                     {
-                        "description": "Booking data including field_id, start_time, and end_time",
+                        "description": "Register request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.CreateBookingRequest"
+                            "$ref": "#/definitions/take-home-test_app_models.RegisterRequest"
                         }
                     }
                 ],
@@ -175,7 +204,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.UserResponse"
                                         }
                                     }
                                 }
@@ -183,19 +212,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input, field not available, or time conflict",
-                        "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - JWT token missing or invalid",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict - Time slot already booked",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
@@ -203,14 +226,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/user": {
+        "/bookings": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all bookings for the authenticated user. **USERS CAN ONLY SEE THEIR OWN BOOKINGS**.",
+                "description": "Get a page of the bookings of every user with their customer, field and payment (admin only). q searches the customer's name, email and phone and the field name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -220,14 +238,71 @@ const docTemplate = `{
                 "tags": [
                     "Bookings"
                 ],
-                "summary": "Get user bookings",
+                "summary": "List all bookings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-start_time",
+                        "description": "Comma-separated sort keys (start_time, end_time, status, created_at); prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses, e.g. paid,confirmed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field ID",
+                        "name": "field_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                    "$ref": "#/definitions/take-home-test_app_models.ResponseWithPaginate"
                                 },
                                 {
                                     "type": "object",
@@ -235,25 +310,35 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                                "$ref": "#/definitions/take-home-test_app_models.BookingDetailResponse"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
                     }
-                }
-            }
-        },
-        "/bookings/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get booking details by ID. **AUTHENTICATED USERS** can access their own bookings. **ADMINS** can access all bookings.",
+                ]
+            },
+            "post": {
+                "description": "Create a new field booking. The price follows the field's pricing rules; an optional voucher_code is applied to it, and a booking left with nothing to pay is marked paid right away.",
                 "consumes": [
                     "application/json"
                 ],
@@ -263,19 +348,27 @@ const docTemplate = `{
                 "tags": [
                     "Bookings"
                 ],
-                "summary": "Get booking by ID",
+                "summary": "Create new booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Booking data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CreateBookingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -292,65 +385,35 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - User cannot access this booking",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found - Booking with specified ID does not exist",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/fields": {
-            "get": {
-                "description": "Get list of all available sports fields. PUBLIC ACCESS - No authentication required.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Fields"
-                ],
-                "summary": "Get all fields",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new sports field. ADMIN ACCESS ONLY - Regular users cannot create fields.",
+                ]
+            }
+        },
+        "/bookings/recurring": {
+            "post": {
+                "description": "Book the same slot weekly or biweekly, count times and/or until a date (YYYY-MM-DD in the venue timezone, inclusive), at most 52 occurrences. Every occurrence is checked against the field's opening hours, closures and existing bookings; if any cannot be booked nothing is booked and the 409 response lists the conflicting occurrences. The whole series is held and paid for with a single payment, started through any of its bookings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -358,17 +421,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fields"
+                    "Bookings"
                 ],
-                "summary": "Create new field",
+                "summary": "Create recurring booking",
                 "parameters": [
                     {
-                        "description": "Field data including name, price_per_hour, and location",
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Recurring booking data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.CreateFieldRequest"
+                            "$ref": "#/definitions/take-home-test_app_models.RecurringBookingRequest"
                         }
                     }
                 ],
@@ -384,7 +453,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingSeriesResponse"
                                         }
                                     }
                                 }
@@ -403,18 +472,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingConflictsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/fields/{id}": {
+        "/bookings/user": {
             "get": {
-                "description": "Get details of a specific sports field. PUBLIC ACCESS - No authentication required.",
+                "description": "Get a page of the authenticated user's bookings, optionally filtered by status, field and date range. from and to accept RFC3339 or a date in the venue timezone and match bookings overlapping the range; a date used as \"to\" includes the whole day.",
                 "consumes": [
                     "application/json"
                 ],
@@ -422,16 +508,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fields"
+                    "Bookings"
                 ],
-                "summary": "Get field by ID",
+                "summary": "Get user bookings",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Field ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "-start_time",
+                        "description": "Comma-separated sort keys (start_time, end_time, status, created_at); prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses, e.g. pending,paid",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field ID",
+                        "name": "field_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -440,34 +562,39 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                    "$ref": "#/definitions/take-home-test_app_models.ResponseWithPaginate"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update sports field information. ADMIN ACCESS ONLY - Regular users cannot update fields.",
+                ]
+            }
+        },
+        "/bookings/walk-in": {
+            "post": {
+                "description": "Book a field for a customer at the counter (admin only). Pass user_id for a customer with an account, otherwise customer_name. The booking is confirmed right away; with a payment_method it is also recorded as paid in full.",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,30 +602,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fields"
+                    "Bookings"
                 ],
-                "summary": "Update field",
+                "summary": "Create walk-in booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Field ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
-                        "description": "Field update data",
+                        "description": "Walk-in booking data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.UpdateFieldRequest"
+                            "$ref": "#/definitions/take-home-test_app_models.WalkInBookingRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -508,7 +634,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingDetailResponse"
                                         }
                                     }
                                 }
@@ -532,16 +658,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete a sports field. ADMIN ACCESS ONLY - Regular users cannot delete fields.",
+                ]
+            }
+        },
+        "/bookings/{id}": {
+            "get": {
+                "description": "Get booking details by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,13 +683,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fields"
+                    "Bookings"
                 ],
-                "summary": "Delete field",
+                "summary": "Get booking by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Field ID (UUID format)",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -565,7 +699,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "403": {
@@ -580,17 +726,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            }
-        },
-        "/payments": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Process mock payment for a booking. **AUTHENTICATED USERS** can pay for their own bookings. This is a mock implementation for testing.",
+                ]
+            }
+        },
+        "/bookings/{id}/cancel": {
+            "post": {
+                "description": "Cancel a booking that has not started yet (owner or admin). A paid booking is refunded according to the cancellation policy: full refund until REFUND_FULL_BEFORE before the start, REFUND_PARTIAL_PERCENT until REFUND_CUTOFF, nothing afterwards.",
                 "consumes": [
                     "application/json"
                 ],
@@ -598,17 +744,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Bookings"
                 ],
-                "summary": "Process payment (Mock)",
+                "summary": "Cancel booking",
                 "parameters": [
                     {
-                        "description": "Payment data including booking_id and payment_method",
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/take-home-test_app_models.CreatePaymentRequest"
+                            "$ref": "#/definitions/take-home-test_app_models.CancelBookingRequest"
                         }
                     }
                 ],
@@ -624,7 +776,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.CancelBookingResponse"
                                         }
                                     }
                                 }
@@ -632,63 +784,34 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input or booking not found",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - User cannot pay for this booking",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/payments/notification": {
-            "post": {
-                "description": "Webhook endpoint for Midtrans payment notifications. **NO AUTHENTICATION REQUIRED** - This endpoint is called by Midtrans server to update payment status. Do not call this endpoint directly.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Payments"
-                ],
-                "summary": "Handle payment notification webhook",
-                "parameters": [
-                    {
-                        "description": "Midtrans notification payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            }
-        },
-        "/payments/{booking_id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get payment details for a specific booking. **USERS** can access payments for their own bookings. **ADMINS** can access all payments.",
+                ]
+            }
+        },
+        "/bookings/{id}/confirm": {
+            "post": {
+                "description": "Confirm a pending or paid booking by hand, e.g. one that will be paid at the venue (admin only). A confirmed booking no longer expires when its payment hold runs out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -696,14 +819,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Bookings"
                 ],
-                "summary": "Get payment by booking ID",
+                "summary": "Confirm booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID (UUID format)",
-                        "name": "booking_id",
+                        "description": "Booking ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -720,36 +843,42 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
                     "403": {
-                        "description": "Forbidden - User cannot access this payment",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found - Payment for specified booking does not exist",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            }
-        },
-        "/payments/{booking_id}/transaction": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create Midtrans payment transaction for a booking. Returns payment URL for redirection to Midtrans payment page. **AUTHENTICATED USERS** can create transactions for their own bookings.",
+                ]
+            }
+        },
+        "/bookings/{id}/details": {
+            "get": {
+                "description": "Get a booking with its customer, field and payment, including payment attempts, refunds and the status history of the booking and its payment (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -757,14 +886,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Bookings"
                 ],
-                "summary": "Create real payment transaction (Midtrans)",
+                "summary": "Get booking details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID (UUID format)",
-                        "name": "booking_id",
+                        "description": "Booking ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -781,7 +910,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingDetailResponse"
                                         }
                                     }
                                 }
@@ -789,28 +918,34 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid booking or payment error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - User cannot create payment for this booking",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/profile": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get authenticated user's profile information",
+                ]
+            }
+        },
+        "/bookings/{id}/no-show": {
+            "post": {
+                "description": "Record that the customer of a paid, confirmed or completed booking did not turn up (admin only). Only possible once the booking has started; nothing is refunded.",
                 "consumes": [
                     "application/json"
                 ],
@@ -818,42 +953,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Bookings"
+                ],
+                "summary": "Mark booking as no-show",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get user details by ID (Admin only)",
+                ]
+            }
+        },
+        "/bookings/{id}/reschedule": {
+            "patch": {
+                "description": "Move a pending, partially paid, paid or confirmed booking that has not started yet to another slot of the same field (owner or admin). The new slot is validated like a new booking, apart from overlapping the booking itself, and priced again. An unpaid booking is paid at the new amount as usual; a paid booking that became more expensive gets a transaction for the difference, and what was paid beyond the new amount is kept as credit. A booking secured with a deposit keeps its balance to be paid later.",
                 "consumes": [
                     "application/json"
                 ],
@@ -861,46 +1020,4646 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Bookings"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Reschedule booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New time",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.RescheduleBookingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.RescheduleBookingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/bookings/{id}/split": {
+            "get": {
+                "description": "Get the split payment of a booking with the status of each share (owner or admin).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split Payments"
+                ],
+                "summary": "Get split payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.SplitPaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Split the payment of an unpaid booking evenly between its owner and 1 to 9 invited emails (owner only); the owner's share takes what does not divide evenly. The booking stays held until the deadline (default SPLIT_PAYMENT_TTL, at most until the booking starts). Each participant pays their share through POST /payments/shares/{id}/transaction, and the booking is paid once every share is paid or the owner covers the rest. Unpaid by the deadline, the booking is canceled and the paid shares are refunded. Invited emails registered with an account get a notification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split Payments"
+                ],
+                "summary": "Split booking payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Participants and deadline",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.SplitPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.SplitPaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/bookings/{id}/split/cover": {
+            "post": {
+                "description": "Create a Midtrans transaction for the booking owner paying whatever the shares have not paid yet, before the deadline (owner only). Open transactions of the shares are expired; once it settles the booking is paid and the unpaid shares become covered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split Payments"
+                ],
+                "summary": "Cover unpaid shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/cart": {
+            "get": {
+                "description": "Get the authenticated user's open cart with its bookings and total.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Get cart",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart/checkout": {
+            "post": {
+                "description": "Close the authenticated user's open cart and pay for all of its bookings with a single payment. Its bookings are held for another BOOKING_HOLD_TTL and the returned transaction lists each of them as an item; once it settles every booking of the cart is paid. When the transaction cannot be created it can be retried through POST /payments/{booking_id}/transaction with any booking of the cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Check out cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CheckoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "description": "Hold a slot in the authenticated user's cart, opening one when there is none. The slot is checked like a new booking and held as a pending booking until the cart expires, BOOKING_HOLD_TTL after it was opened. A cart holds at most 10 bookings, on any fields.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Add cart item",
+                "parameters": [
+                    {
+                        "description": "Slot to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart/items/{booking_id}": {
+            "delete": {
+                "description": "Remove a booking from the authenticated user's open cart, canceling it and freeing its slot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields": {
+            "get": {
+                "description": "Get a page of the available sports fields, optionally filtered by name, location and price range. PUBLIC ACCESS - No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get all fields",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Comma-separated sort keys (name, location, price_per_hour, created_at); prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the field name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price per hour",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price per hour",
+                        "name": "max_price",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.ResponseWithPaginate"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new sports field. ADMIN ACCESS ONLY - Regular users cannot create fields. A deposit_percent between 1 and 100 lets bookings of the field, including recurring series and carts, be secured with that share of their amount, the rest being paid later; 0 asks for the full amount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Create new field",
+                "parameters": [
+                    {
+                        "description": "Field data including name, price_per_hour, and location",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CreateFieldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}": {
+            "get": {
+                "description": "Get details of a specific sports field. PUBLIC ACCESS - No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get field by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update sports field information. ADMIN ACCESS ONLY - Regular users cannot update fields. A deposit_percent between 1 and 100 lets bookings of the field, including recurring series and carts, be secured with that share of their amount, the rest being paid later; 0 asks for the full amount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Update field",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.UpdateFieldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a sports field. ADMIN ACCESS ONLY - Regular users cannot delete fields.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Delete field",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/availability": {
+            "get": {
+                "description": "List the free and taken slots of a field between from and to. PUBLIC ACCESS - No authentication required. A plain date (YYYY-MM-DD) is read in the venue timezone; a date used as \"to\" includes the whole day. Slots outside opening hours, during a maintenance blackout or on a holiday are closed. Defaults to today with 60 minute slots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get field availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slot length, e.g. 30m or 60m",
+                        "name": "slot",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.FieldAvailabilityResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fields/{id}/blackouts": {
+            "get": {
+                "description": "List the maintenance blackouts of a field. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get field blackouts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.FieldBlackoutResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Close a field for maintenance between start_time and end_time. ADMIN ACCESS ONLY. Bookings that collide with the blackout are listed in the response; with cancel_conflicts they are also canceled with a full refund.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Create field blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Blackout window",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CreateFieldBlackoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CreateFieldBlackoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/blackouts/{blackout_id}": {
+            "delete": {
+                "description": "Remove a maintenance blackout, reopening the field for booking. ADMIN ACCESS ONLY. Bookings canceled because of it are not restored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Delete field blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blackout ID (UUID format)",
+                        "name": "blackout_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/blackouts/{blackout_id}/cancel-conflicts": {
+            "post": {
+                "description": "Cancel every booking that has not ended yet and overlaps a maintenance blackout, refunding everything that was paid. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Cancel bookings colliding with a blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blackout ID (UUID format)",
+                        "name": "blackout_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BlackoutConflictsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/blackouts/{blackout_id}/conflicts": {
+            "get": {
+                "description": "List the bookings that have not ended yet and overlap a maintenance blackout. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get bookings colliding with a blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blackout ID (UUID format)",
+                        "name": "blackout_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BlackoutConflictsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/pricing-rules": {
+            "get": {
+                "description": "List the pricing rules of a field. PUBLIC ACCESS - No authentication required. Time not covered by any rule is charged at the field's price_per_hour.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get field pricing rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.PricingRuleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a pricing rule to a field. ADMIN ACCESS ONLY. day_type is all, weekday, weekend, holiday or a day name (monday to sunday); start_time and end_time are HH:MM in the venue timezone (end_time may be 24:00, both default to the whole day). Where rules overlap the highest priority wins, then the most specific day type (holiday, day name, weekday/weekend, all).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Create field pricing rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PricingRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/pricing-rules/{rule_id}": {
+            "put": {
+                "description": "Replace a pricing rule of a field. ADMIN ACCESS ONLY. Prices of existing bookings are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Update field pricing rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pricing rule ID (UUID format)",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PricingRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove a pricing rule from a field. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Delete field pricing rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pricing rule ID (UUID format)",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/quote": {
+            "get": {
+                "description": "Price a booking of the field from start to end without creating it. PUBLIC ACCESS - No authentication required. Every minute is charged at the hourly price of the matching pricing rule, or the field's price_per_hour; the lines show how the amount is made up. Availability is not checked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get price quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking start (RFC3339)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking end (RFC3339)",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PriceQuoteResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fields/{id}/schedule": {
+            "get": {
+                "description": "Get the weekly opening hours of a field. PUBLIC ACCESS - No authentication required. An empty list means the field has no opening hours configured and can be booked at any time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Get field opening hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.FieldScheduleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the complete weekly opening hours of a field. ADMIN ACCESS ONLY. Days are monday to sunday, times are HH:MM in the venue timezone (close_time may be 24:00), and ranges of the same day must not overlap. An empty list removes the opening hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Replace field opening hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly opening hours",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.ReplaceFieldScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.FieldScheduleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Add one opening range to a field's weekly schedule. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Add field opening hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Opening range",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.FieldScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.FieldScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/fields/{id}/schedule/{schedule_id}": {
+            "delete": {
+                "description": "Remove one opening range from a field's weekly schedule. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fields"
+                ],
+                "summary": "Delete field opening hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID (UUID format)",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/holidays": {
+            "get": {
+                "description": "List the dates on which the whole venue is closed. PUBLIC ACCESS - No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Get holidays",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.HolidayResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Close every field for a whole day in the venue timezone. ADMIN ACCESS ONLY. A date that already is a holiday is renamed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Create holiday",
+                "parameters": [
+                    {
+                        "description": "Holiday",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.HolidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.HolidayResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/holidays/import": {
+            "post": {
+                "description": "Add the dates of every event in an iCalendar (.ics) file as holidays named after the event. ADMIN ACCESS ONLY. Send the file as multipart form field \"file\" or as the raw text/calendar body. Dates that already are holidays are renamed.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/calendar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Import holidays from iCalendar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "iCalendar file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.ImportHolidaysResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/holidays/{id}": {
+            "delete": {
+                "description": "Reopen the venue on a holiday. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Delete holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holiday ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Get a page of the authenticated user's notifications, latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only notifications not read yet",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.ResponseWithPaginate"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.NotificationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "description": "Mark one of the authenticated user's notifications as read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments": {
+            "post": {
+                "description": "Process mock payment for a booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Process payment (Mock)",
+                "parameters": [
+                    {
+                        "description": "Payment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/fake/{order_id}": {
+            "get": {
+                "description": "Show a transaction of the offline fake payment gateway. Only available when PAYMENT_GATEWAY=fake.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get fake gateway transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Move a fake gateway transaction to a new status (settlement, capture, deny, cancel, expire, failure) and process the resulting notification. Only available when PAYMENT_GATEWAY=fake.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Simulate fake gateway payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Simulated status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.SimulatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/notification": {
+            "post": {
+                "description": "Webhook endpoint for Midtrans payment notifications. The signature_key and gross_amount are verified, and duplicated or replayed notifications are acknowledged without being applied again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Handle payment notification webhook",
+                "parameters": [
+                    {
+                        "description": "Midtrans notification payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/shares/{id}/transaction": {
+            "post": {
+                "description": "Create a Midtrans transaction for the authenticated user paying their share of a split payment before its deadline. A transaction opened for the share before is expired.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split Payments"
+                ],
+                "summary": "Pay payment share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{booking_id}": {
+            "get": {
+                "description": "Get payment details for a specific booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get payment by booking ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{booking_id}/cash": {
+            "post": {
+                "description": "Record cash taken at the counter for a booking (admin only), typically the balance left after a deposit. Omit amount to pay everything still due. Open gateway transactions of the booking are expired; the booking is paid once nothing is due, or partially paid when at least its deposit is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Record a cash payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cash payment data",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.CashPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{booking_id}/refunds": {
+            "post": {
+                "description": "Refund part or all of a booking's payment through the payment gateway (admin only). Omit amount to refund everything not refunded yet. The booking status is not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Refund a payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.RefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{booking_id}/transaction": {
+            "post": {
+                "description": "Create Midtrans payment transaction for a booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Create real payment transaction (Midtrans)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/credits": {
+            "get": {
+                "description": "Get the authenticated user's credit balance and its history, e.g. the difference kept when a paid booking was rescheduled to a cheaper slot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user credits",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.CreditBalanceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "description": "Get authenticated user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/shares": {
+            "get": {
+                "description": "List the shares of split payments the authenticated user was invited to pay, matched by their email, latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split Payments"
+                ],
+                "summary": "Get user payment shares",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.PaymentShareResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get user details by ID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}/role": {
+            "patch": {
+                "description": "Promote a user to admin or demote an admin to user. ADMIN ACCESS ONLY - The user's sessions are revoked so the new role applies immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/vouchers": {
+            "get": {
+                "description": "List every voucher with its usage. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Get vouchers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.VoucherResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a promo code. ADMIN ACCESS ONLY. A percentage voucher takes discount_value percent off (capped by max_discount), a fixed voucher takes discount_value off. Omitted limits, validity bounds and field_ids mean unlimited; codes are case-insensitive.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Create voucher",
+                "parameters": [
+                    {
+                        "description": "Voucher",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.VoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.VoucherResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/vouchers/{id}": {
+            "get": {
+                "description": "Get a voucher with its usage. ADMIN ACCESS ONLY.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Get voucher by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voucher ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.VoucherResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replace the settings of a voucher; set active to false to withdraw it. ADMIN ACCESS ONLY. Discounts already given are not changed, and usage_limit cannot go below used_count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Update voucher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voucher ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voucher",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.VoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.VoucherResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/waitlist": {
+            "get": {
+                "description": "List the authenticated user's waitlist entries, latest first. An offered entry shows the held booking and until when it can be confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Get user waitlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/take-home-test_app_models.WaitlistEntryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Wait for a taken slot of a field. When a booking overlapping the slot is canceled or expires, the oldest waiting user whose whole slot became free gets it held for WAITLIST_OFFER_TTL and a notification. A slot that is still free must be booked directly.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Join waitlist",
+                "parameters": [
+                    {
+                        "description": "Slot to wait for",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.WaitlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.WaitlistEntryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/waitlist/{id}": {
+            "delete": {
+                "description": "Leave the waitlist. An open offer is declined and the slot passes to the next user waiting for it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Leave waitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.WaitlistEntryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/waitlist/{id}/confirm": {
+            "post": {
+                "description": "Turn the slot held for a waitlist entry into a regular pending booking at the current price. It is then paid like any new booking within BOOKING_HOLD_TTL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Confirm waitlist offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/take-home-test_app_models.BasicResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "take-home-test_app_models.AvailabilitySlot": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.BasicResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {},
+                "pagination": {
+                    "$ref": "#/definitions/take-home-test_app_models.Pagination"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "take-home-test_app_models.BlackoutConflictsResponse": {
+            "type": "object",
+            "properties": {
+                "canceled": {
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.BookingConflict": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.BookingConflictsResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.BookingConflict"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.BookingDetailResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field": {
+                    "$ref": "#/definitions/take-home-test_app_models.FieldResponse"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.StatusHistoryResponse"
+                    }
+                },
+                "hold_expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment": {
+                    "$ref": "#/definitions/take-home-test_app_models.PaymentResponse"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/take-home-test_app_models.UserResponse"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.BookingResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "hold_expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.BookingSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.CancelBookingRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.CancelBookingResponse": {
+            "type": "object",
+            "properties": {
+                "booking": {
+                    "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                },
+                "refund_amount": {
+                    "type": "integer"
+                },
+                "refund_percent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "take-home-test_app_models.CartItemRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "field_id",
+                "start_time"
+            ],
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.CartResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                    }
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 300000
+                }
+            }
+        },
+        "take-home-test_app_models.CashPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 210000
+                }
+            }
+        },
+        "take-home-test_app_models.CheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "cart": {
+                    "$ref": "#/definitions/take-home-test_app_models.CartResponse"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
+                }
+            }
+        },
+        "take-home-test_app_models.CreateBookingRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "field_id",
+                "start_time"
+            ],
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "voucher_code": {
+                    "type": "string",
+                    "example": "WEEKEND20"
+                }
+            }
+        },
+        "take-home-test_app_models.CreateFieldBlackoutRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "cancel_conflicts": {
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Turf replacement"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.CreateFieldBlackoutResponse": {
+            "type": "object",
+            "properties": {
+                "blackout": {
+                    "$ref": "#/definitions/take-home-test_app_models.FieldBlackoutResponse"
+                },
+                "canceled": {
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.CreateFieldRequest": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "price_per_hour"
+            ],
+            "properties": {
+                "deposit_percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 30
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_per_hour": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "take-home-test_app_models.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "booking_id",
+                "payment_method"
+            ],
+            "properties": {
+                "booking_id": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.CreditBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.CreditResponse"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.CreditResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.FieldAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "field_id": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "string"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.AvailabilitySlot"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.FieldBlackoutResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.FieldResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_percent": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_per_hour": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.FieldScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "monday"
+                },
+                "open_time": {
+                    "type": "string",
+                    "example": "08:00"
+                }
+            }
+        },
+        "take-home-test_app_models.FieldScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "type": "string"
+                },
+                "day_of_week": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "open_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.HolidayRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-17"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hari Kemerdekaan"
+                }
+            }
+        },
+        "take-home-test_app_models.HolidayResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.ImportHolidaysResponse": {
+            "type": "object",
+            "properties": {
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.HolidayResponse"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "take-home-test_app_models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/take-home-test_app_models.UserResponse"
+                }
+            }
+        },
+        "take-home-test_app_models.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "waitlist_offer"
+                }
+            }
+        },
+        "take-home-test_app_models.Pagination": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "take-home-test_app_models.PaymentAttemptResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "redirect_url": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "type": "integer"
+                },
+                "share_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.PaymentAttemptResponse"
+                    }
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_amount": {
+                    "type": "integer"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "original_amount": {
+                    "type": "integer"
+                },
+                "paid_amount": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "type": "integer"
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.RefundResponse"
+                    }
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.PaymentShareResponse"
+                    }
+                },
+                "split_deadline": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "voucher_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.PaymentShareResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 30000
+                },
+                "booking_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "take-home-test_app_models.PaymentTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "attempt_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "redirect_url": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.PriceQuoteLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "price_per_hour": {
+                    "type": "integer"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.PriceQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.PriceQuoteLine"
+                    }
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.PricingRuleRequest": {
+            "type": "object",
+            "properties": {
+                "day_type": {
+                    "type": "string",
+                    "example": "weekend"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "24:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekend evening"
+                },
+                "price_per_hour": {
+                    "type": "integer",
+                    "example": 150000
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "17:00"
+                }
+            }
+        },
+        "take-home-test_app_models.PricingRuleResponse": {
+            "type": "object",
+            "properties": {
+                "day_type": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_per_hour": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.RecurringBookingRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "field_id",
+                "frequency",
+                "start_time"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "field_id": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "weekly"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string",
+                    "example": "2025-12-31"
+                }
+            }
+        },
+        "take-home-test_app_models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.RefundRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Floodlights broken during the match"
+                }
+            }
+        },
+        "take-home-test_app_models.RefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "attempt_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "take-home-test_app_models.ReplaceFieldScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.FieldScheduleRequest"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.RescheduleBookingRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.RescheduleBookingResponse": {
+            "type": "object",
+            "properties": {
+                "amount_due": {
+                    "type": "integer",
+                    "example": 25000
+                },
+                "booking": {
+                    "$ref": "#/definitions/take-home-test_app_models.BookingResponse"
+                },
+                "credit": {
+                    "type": "integer"
+                },
+                "price_difference": {
+                    "type": "integer",
+                    "example": 25000
+                },
+                "transaction": {
+                    "$ref": "#/definitions/take-home-test_app_models.PaymentTransactionResponse"
                 }
             }
-        }
-    },
-    "definitions": {
-        "take-home-test_app_models.BasicResponse": {
+        },
+        "take-home-test_app_models.ResponseWithPaginate": {
             "type": "object",
             "properties": {
                 "data": {},
@@ -913,52 +5672,121 @@ const docTemplate = `{
                 }
             }
         },
-        "take-home-test_app_models.BookingResponse": {
+        "take-home-test_app_models.SimulatePaymentRequest": {
             "type": "object",
+            "required": [
+                "transaction_status"
+            ],
             "properties": {
-                "created_at": {
+                "payment_type": {
                     "type": "string"
                 },
-                "end_time": {
+                "transaction_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "take-home-test_app_models.SplitPaymentRequest": {
+            "type": "object",
+            "required": [
+                "emails"
+            ],
+            "properties": {
+                "deadline": {
                     "type": "string"
                 },
-                "field_id": {
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "budi@example.com",
+                        "sari@example.com"
+                    ]
+                }
+            }
+        },
+        "take-home-test_app_models.SplitPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 300000
+                },
+                "booking_id": {
                     "type": "string"
                 },
-                "id": {
+                "deadline": {
                     "type": "string"
                 },
-                "start_time": {
+                "paid_amount": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "payment_id": {
                     "type": "string"
                 },
-                "status": {
+                "remaining": {
+                    "type": "integer",
+                    "example": 240000
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/take-home-test_app_models.PaymentShareResponse"
+                    }
+                }
+            }
+        },
+        "take-home-test_app_models.StatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "booking"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "reason": {
                     "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "gateway"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "paid"
                 }
             }
         },
-        "take-home-test_app_models.CreateBookingRequest": {
+        "take-home-test_app_models.TokenResponse": {
             "type": "object",
-            "required": [
-                "end_time",
-                "field_id",
-                "start_time"
-            ],
             "properties": {
-                "end_time": {
+                "expires_at": {
                     "type": "string"
                 },
-                "field_id": {
+                "refresh_expires_at": {
                     "type": "string"
                 },
-                "start_time": {
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "take-home-test_app_models.CreateFieldRequest": {
+        "take-home-test_app_models.UpdateFieldRequest": {
             "type": "object",
             "required": [
                 "location",
@@ -966,6 +5794,12 @@ const docTemplate = `{
                 "price_per_hour"
             ],
             "properties": {
+                "deposit_percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 30
+                },
                 "location": {
                     "type": "string"
                 },
@@ -978,197 +5812,227 @@ const docTemplate = `{
                 }
             }
         },
-        "take-home-test_app_models.CreatePaymentRequest": {
+        "take-home-test_app_models.UpdateUserRoleRequest": {
             "type": "object",
             "required": [
-                "booking_id",
-                "payment_method"
+                "role"
             ],
             "properties": {
-                "booking_id": {
-                    "type": "string"
-                },
-                "payment_method": {
-                    "type": "string"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ]
                 }
             }
         },
-        "take-home-test_app_models.FieldResponse": {
+        "take-home-test_app_models.UserResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "location": {
+                "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "price_per_hour": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "take-home-test_app_models.LoginRequest": {
+        "take-home-test_app_models.VoucherRequest": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "password": {
-                    "type": "string"
-                }
-            }
-        },
-        "take-home-test_app_models.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "example": "WEEKEND20"
                 },
-                "user": {
-                    "$ref": "#/definitions/take-home-test_app_models.UserResponse"
-                }
-            }
-        },
-        "take-home-test_app_models.Pagination": {
-            "type": "object",
-            "properties": {
-                "page": {
-                    "type": "integer"
+                "description": {
+                    "type": "string",
+                    "example": "20% off weekend bookings"
                 },
-                "page_size": {
-                    "type": "integer"
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
                 },
-                "total": {
-                    "type": "integer"
+                "discount_value": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "total_page": {
+                "field_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_discount": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "min_amount": {
                     "type": "integer"
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
                 }
             }
         },
-        "take-home-test_app_models.PaymentResponse": {
+        "take-home-test_app_models.VoucherResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer"
+                "active": {
+                    "type": "boolean"
                 },
-                "booking_id": {
+                "code": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "paid_at": {
+                "discount_type": {
                     "type": "string"
                 },
-                "payment_method": {
+                "discount_value": {
+                    "type": "integer"
+                },
+                "field_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_amount": {
+                    "type": "integer"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "used_count": {
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
                     "type": "string"
                 }
             }
         },
-        "take-home-test_app_models.PaymentTransactionResponse": {
+        "take-home-test_app_models.WaitlistEntryResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
-                "payment_id": {
+                "booking_id": {
                     "type": "string"
                 },
-                "redirect_url": {
+                "created_at": {
                     "type": "string"
                 },
-                "token": {
+                "end_time": {
                     "type": "string"
                 },
-                "transaction_id": {
+                "field_id": {
                     "type": "string"
-                }
-            }
-        },
-        "take-home-test_app_models.RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password",
-                "role"
-            ],
-            "properties": {
-                "email": {
+                },
+                "id": {
                     "type": "string"
                 },
-                "name": {
+                "offer_expires_at": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "start_time": {
+                    "type": "string"
                 },
-                "role": {
+                "status": {
                     "type": "string",
-                    "enum": [
-                        "user",
-                        "admin"
-                    ]
+                    "example": "waiting"
                 }
             }
         },
-        "take-home-test_app_models.UpdateFieldRequest": {
+        "take-home-test_app_models.WaitlistRequest": {
             "type": "object",
             "required": [
-                "location",
-                "name",
-                "price_per_hour"
+                "end_time",
+                "field_id",
+                "start_time"
             ],
             "properties": {
-                "location": {
+                "end_time": {
                     "type": "string"
                 },
-                "name": {
+                "field_id": {
                     "type": "string"
                 },
-                "price_per_hour": {
-                    "type": "integer",
-                    "minimum": 0
+                "start_time": {
+                    "type": "string"
                 }
             }
         },
-        "take-home-test_app_models.UserResponse": {
+        "take-home-test_app_models.WalkInBookingRequest": {
             "type": "object",
+            "required": [
+                "end_time",
+                "field_id",
+                "start_time"
+            ],
             "properties": {
-                "created_at": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "Budi"
+                },
+                "customer_phone": {
+                    "type": "string",
+                    "example": "081234567890"
+                },
+                "end_time": {
                     "type": "string"
                 },
-                "email": {
+                "field_id": {
                     "type": "string"
                 },
-                "id": {
+                "payment_method": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "start_time": {
                     "type": "string"
                 },
-                "name": {
+                "user_id": {
                     "type": "string"
                 },
-                "role": {
+                "voucher_code": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS fields (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    price_per_hour INTEGER NOT NULL,
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fields_name_key UNIQUE (name),
+    CONSTRAINT fields_price_per_hour_check CHECK (price_per_hour >= 0)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS payment_notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notification_key VARCHAR(64) NOT NULL,
+    order_id VARCHAR(100) NOT NULL,
+    transaction_id VARCHAR(100) NOT NULL DEFAULT '',
+    transaction_status VARCHAR(50) NOT NULL DEFAULT '',
+    status_code VARCHAR(10) NOT NULL DEFAULT '',
+    gross_amount VARCHAR(50) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT payment_notifications_notification_key_key UNIQUE (notification_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_notifications_order_id ON payment_notifications (order_id);
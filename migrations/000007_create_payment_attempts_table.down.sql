@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS payment_attempts;
+ALTER TABLE payments DROP COLUMN IF EXISTS paid_amount;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_booking_id_key;
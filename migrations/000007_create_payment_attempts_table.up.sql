@@ -0,0 +1,36 @@
+-- Retries used to insert a new payments row per attempt; keep a single
+-- payment per booking (preferring a successful one) before making it unique.
+DELETE FROM payments
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY booking_id
+            ORDER BY (status = 'success') DESC, created_at ASC
+        ) AS rn
+        FROM payments
+    ) ranked
+    WHERE rn > 1
+);
+
+ALTER TABLE payments ADD CONSTRAINT payments_booking_id_key UNIQUE (booking_id);
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS paid_amount INTEGER NOT NULL DEFAULT 0;
+UPDATE payments SET paid_amount = amount WHERE status = 'success';
+
+CREATE TABLE IF NOT EXISTS payment_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+    order_id VARCHAR(50) NOT NULL,
+    amount INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    payment_method VARCHAR(50) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(100) NOT NULL DEFAULT '',
+    snap_token VARCHAR(255) NOT NULL DEFAULT '',
+    redirect_url TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT payment_attempts_order_id_key UNIQUE (order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_attempts_payment_id ON payment_attempts (payment_id);
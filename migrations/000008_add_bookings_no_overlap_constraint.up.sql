@@ -0,0 +1,10 @@
+-- Overlapping bookings of the same field are rejected by the database itself,
+-- so concurrent requests cannot both pass the application-level check.
+-- Existing overlaps must be resolved (canceled) before this migration runs.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        field_id WITH =,
+        tstzrange(start_time, end_time, '[)') WITH &&
+    ) WHERE (status <> 'canceled');
@@ -0,0 +1,2 @@
+ALTER TABLE payment_attempts DROP COLUMN IF EXISTS refunded_amount;
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
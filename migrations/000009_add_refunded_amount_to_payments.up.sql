@@ -0,0 +1,2 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE payment_attempts ADD COLUMN IF NOT EXISTS refunded_amount INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bookings_pending_hold_expires_at;
+ALTER TABLE bookings DROP COLUMN IF EXISTS hold_expires_at;
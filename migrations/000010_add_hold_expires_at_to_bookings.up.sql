@@ -0,0 +1,7 @@
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS hold_expires_at TIMESTAMPTZ;
+
+UPDATE bookings SET hold_expires_at = created_at + INTERVAL '15 minutes'
+WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_bookings_pending_hold_expires_at ON bookings (hold_expires_at)
+    WHERE status = 'pending';
@@ -0,0 +1,19 @@
+-- Weekly opening hours of a field; several ranges per day are allowed. Times
+-- are HH:MM in the venue timezone, with 24:00 closing at midnight.
+CREATE TABLE IF NOT EXISTS field_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    day_of_week VARCHAR(10) NOT NULL,
+    open_time VARCHAR(5) NOT NULL,
+    close_time VARCHAR(5) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT field_schedules_day_check CHECK (
+        day_of_week IN ('monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday')
+    ),
+    CONSTRAINT field_schedules_open_time_check CHECK (open_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    CONSTRAINT field_schedules_close_time_check CHECK (close_time ~ '^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$'),
+    CONSTRAINT field_schedules_range_check CHECK (close_time > open_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_field_schedules_field_id ON field_schedules (field_id, day_of_week);
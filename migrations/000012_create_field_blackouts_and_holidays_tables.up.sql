@@ -0,0 +1,24 @@
+-- Maintenance windows during which a single field cannot be booked
+CREATE TABLE IF NOT EXISTS field_blackouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT field_blackouts_range_check CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_field_blackouts_field_id ON field_blackouts (field_id, start_time);
+
+-- Venue-wide closures; every field is closed for the whole date, taken in the
+-- venue timezone
+CREATE TABLE IF NOT EXISTS holidays (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT holidays_date_key UNIQUE (date)
+);
@@ -0,0 +1,24 @@
+-- Prices of a field that differ from its base price_per_hour for part of the
+-- day. Times are HH:MM in the venue timezone, with 24:00 ending at midnight.
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    day_type VARCHAR(10) NOT NULL DEFAULT 'all',
+    start_time VARCHAR(5) NOT NULL DEFAULT '00:00',
+    end_time VARCHAR(5) NOT NULL DEFAULT '24:00',
+    price_per_hour INTEGER NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT pricing_rules_day_type_check CHECK (
+        day_type IN ('all', 'weekday', 'weekend', 'holiday',
+            'monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday')
+    ),
+    CONSTRAINT pricing_rules_start_time_check CHECK (start_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    CONSTRAINT pricing_rules_end_time_check CHECK (end_time ~ '^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$'),
+    CONSTRAINT pricing_rules_range_check CHECK (end_time > start_time),
+    CONSTRAINT pricing_rules_price_check CHECK (price_per_hour >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pricing_rules_field_id ON pricing_rules (field_id);
@@ -0,0 +1,8 @@
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS voucher_id,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS original_amount;
+
+DROP TABLE IF EXISTS voucher_redemptions;
+DROP TABLE IF EXISTS voucher_fields;
+DROP TABLE IF EXISTS vouchers;
@@ -0,0 +1,56 @@
+-- Promo codes. A percentage voucher takes discount_value percent off, capped
+-- by max_discount; a fixed voucher takes discount_value off. NULL limits and
+-- validity bounds mean unlimited.
+CREATE TABLE IF NOT EXISTS vouchers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(32) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value INTEGER NOT NULL,
+    max_discount INTEGER,
+    min_amount INTEGER NOT NULL DEFAULT 0,
+    usage_limit INTEGER,
+    per_user_limit INTEGER,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT vouchers_code_key UNIQUE (code),
+    CONSTRAINT vouchers_discount_type_check CHECK (discount_type IN ('percentage', 'fixed')),
+    CONSTRAINT vouchers_discount_value_check CHECK (
+        discount_value > 0 AND (discount_type <> 'percentage' OR discount_value <= 100)
+    ),
+    CONSTRAINT vouchers_used_count_check CHECK (usage_limit IS NULL OR used_count <= usage_limit),
+    CONSTRAINT vouchers_validity_check CHECK (valid_until IS NULL OR valid_from IS NULL OR valid_until > valid_from)
+);
+
+-- Fields a voucher is restricted to; a voucher without rows here is valid
+-- for every field
+CREATE TABLE IF NOT EXISTS voucher_fields (
+    voucher_id UUID NOT NULL REFERENCES vouchers (id) ON DELETE CASCADE,
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    PRIMARY KEY (voucher_id, field_id)
+);
+
+CREATE TABLE IF NOT EXISTS voucher_redemptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    voucher_id UUID NOT NULL REFERENCES vouchers (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+    discount_amount INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT voucher_redemptions_booking_id_key UNIQUE (booking_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_voucher_redemptions_voucher_user ON voucher_redemptions (voucher_id, user_id);
+
+-- amount stays the amount to pay; original_amount and discount_amount
+-- explain how it was reached
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS original_amount INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS voucher_id UUID REFERENCES vouchers (id) ON DELETE SET NULL;
+
+UPDATE payments SET original_amount = amount WHERE original_amount = 0;
@@ -0,0 +1,32 @@
+-- One row per refund of a payment attempt. refund_key is the idempotency key
+-- sent to the gateway; a failed refund may be retried with the same key.
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    attempt_id UUID NOT NULL REFERENCES payment_attempts (id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    refund_key VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    source VARCHAR(20) NOT NULL,
+    requested_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    gateway_refund_id VARCHAR(100) NOT NULL DEFAULT '',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT refunds_refund_key_key UNIQUE (refund_key),
+    CONSTRAINT refunds_amount_check CHECK (amount > 0),
+    CONSTRAINT refunds_status_check CHECK (status IN ('pending', 'success', 'failed')),
+    CONSTRAINT refunds_source_check CHECK (source IN ('admin', 'cancellation', 'gateway'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds (payment_id);
+
+-- Refunds made before this table existed were all cancellation refunds
+INSERT INTO refunds (payment_id, attempt_id, booking_id, amount, reason, refund_key, status, source)
+SELECT payment_id, id, booking_id, refunded_amount, 'Booking canceled',
+       order_id || '-r' || refunded_amount, 'success', 'cancellation'
+FROM payment_attempts
+WHERE refunded_amount > 0
+ON CONFLICT (refund_key) DO NOTHING;
@@ -0,0 +1,20 @@
+-- First response to a request sent with an Idempotency-Key header, replayed
+-- when the same user retries with the same key. request_hash covers the
+-- method, URL and body so a reused key with a different request is refused.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'processing',
+    response_status INTEGER NOT NULL DEFAULT 0,
+    response_content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idempotency_keys_user_id_key_key UNIQUE (user_id, idempotency_key),
+    CONSTRAINT idempotency_keys_status_check CHECK (status IN ('processing', 'completed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
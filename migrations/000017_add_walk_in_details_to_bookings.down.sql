@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_bookings_status_start_time;
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_channel_check;
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS customer_phone,
+    DROP COLUMN IF EXISTS customer_name,
+    DROP COLUMN IF EXISTS channel;
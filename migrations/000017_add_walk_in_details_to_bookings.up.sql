@@ -0,0 +1,13 @@
+-- channel tells online bookings from the ones an admin takes at the counter.
+-- A walk-in customer without an account is booked under the admin's user and
+-- identified by customer_name and customer_phone.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS channel VARCHAR(20) NOT NULL DEFAULT 'online',
+    ADD COLUMN IF NOT EXISTS customer_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS customer_phone VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_channel_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_channel_check CHECK (channel IN ('online', 'walk_in'));
+
+CREATE INDEX IF NOT EXISTS idx_bookings_status_start_time ON bookings (status, start_time);
@@ -0,0 +1,17 @@
+-- Every status transition of a booking or payment, with what triggered it:
+-- a user or admin (actor_id), the payment gateway or the system itself.
+CREATE TABLE IF NOT EXISTS status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT status_history_entity_type_check CHECK (entity_type IN ('booking', 'payment')),
+    CONSTRAINT status_history_source_check CHECK (source IN ('user', 'admin', 'gateway', 'system'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_history_entity ON status_history (entity_id, created_at);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_payments_series_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS series_id;
+
+DROP INDEX IF EXISTS idx_bookings_series_id;
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS booking_series;
@@ -0,0 +1,26 @@
+-- A series of bookings of the same slot repeating weekly or every other
+-- week, paid for with a single payment.
+CREATE TABLE IF NOT EXISTS booking_series (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    frequency VARCHAR(20) NOT NULL,
+    occurrences INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT booking_series_frequency_check CHECK (frequency IN ('weekly', 'biweekly')),
+    CONSTRAINT booking_series_occurrences_check CHECK (occurrences > 1)
+);
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES booking_series (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_series_id ON bookings (series_id);
+
+-- The price of the slot itself, before any discount; a series payment covers
+-- the sum of its bookings' prices.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS price INTEGER NOT NULL DEFAULT 0;
+UPDATE bookings SET price = payments.original_amount
+FROM payments
+WHERE payments.booking_id = bookings.id;
+
+-- A series payment stays attached to the first booking of the series
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES booking_series (id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_series_id ON payments (series_id);
@@ -0,0 +1,38 @@
+-- Users waiting for a taken slot of a field. When a booking overlapping the
+-- slot is canceled or expires, the oldest waiting entry is offered the slot
+-- as a held booking (booking_id) until offer_expires_at.
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    field_id UUID NOT NULL REFERENCES fields (id) ON DELETE CASCADE,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    booking_id UUID REFERENCES bookings (id) ON DELETE SET NULL,
+    offer_expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT waitlist_entries_time_range_check CHECK (end_time > start_time),
+    CONSTRAINT waitlist_entries_status_check CHECK (status IN ('waiting', 'offered', 'booked', 'expired', 'canceled'))
+);
+
+-- A user waits at most once for the same slot
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_open
+    ON waitlist_entries (user_id, field_id, start_time, end_time)
+    WHERE status IN ('waiting', 'offered');
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_field_status ON waitlist_entries (field_id, status, start_time);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_booking_id ON waitlist_entries (booking_id);
+
+-- In-app notifications, e.g. a waitlist offer
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    booking_id UUID REFERENCES bookings (id) ON DELETE SET NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id, created_at);
@@ -0,0 +1,14 @@
+-- Money a user keeps with the venue instead of getting it back, e.g. when a
+-- paid booking is rescheduled to a cheaper slot.
+CREATE TABLE IF NOT EXISTS credits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    booking_id UUID REFERENCES bookings (id) ON DELETE SET NULL,
+    payment_id UUID REFERENCES payments (id) ON DELETE SET NULL,
+    amount INTEGER NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT credits_amount_check CHECK (amount <> 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_credits_user_id ON credits (user_id, created_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_payments_order_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS order_id;
+
+DROP INDEX IF EXISTS idx_bookings_order_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS order_id;
+
+DROP TABLE IF EXISTS orders;
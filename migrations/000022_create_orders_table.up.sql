@@ -0,0 +1,21 @@
+-- A cart of bookings checked out and paid for together. While the order is
+-- open its bookings are pending and held until expires_at; a user has at
+-- most one open order.
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT orders_status_check CHECK (status IN ('open', 'checked_out', 'expired'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_open_user_id ON orders (user_id) WHERE status = 'open';
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES orders (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_order_id ON bookings (order_id);
+
+-- An order payment stays attached to the first booking of the order
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES orders (id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id);
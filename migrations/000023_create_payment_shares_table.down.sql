@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_payment_attempts_share_id;
+ALTER TABLE payment_attempts DROP COLUMN IF EXISTS share_id;
+
+DROP TABLE IF EXISTS payment_shares;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS split_deadline;
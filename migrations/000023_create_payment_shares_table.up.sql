@@ -0,0 +1,24 @@
+-- Shares of a payment split among the players of a booking. Every share is
+-- paid by the user with its email through its own attempt (share_id); the
+-- booking owner may cover the shares left unpaid until split_deadline.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS split_deadline TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS payment_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT payment_shares_amount_check CHECK (amount > 0),
+    CONSTRAINT payment_shares_status_check CHECK (status IN ('pending', 'paid', 'covered', 'canceled'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_shares_payment_email ON payment_shares (payment_id, email);
+CREATE INDEX IF NOT EXISTS idx_payment_shares_email ON payment_shares (email, created_at);
+
+ALTER TABLE payment_attempts ADD COLUMN IF NOT EXISTS share_id UUID REFERENCES payment_shares (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_payment_attempts_share_id ON payment_attempts (share_id);
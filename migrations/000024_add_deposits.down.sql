@@ -0,0 +1,4 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS deposit_amount;
+
+ALTER TABLE fields DROP CONSTRAINT IF EXISTS fields_deposit_percent_check;
+ALTER TABLE fields DROP COLUMN IF EXISTS deposit_percent;
@@ -0,0 +1,8 @@
+-- Deposit (down payment) mode: a field may ask for deposit_percent of a
+-- booking's amount up front. The payment keeps the deposit worked out when
+-- the booking was made; once it is paid the booking is partially_paid and
+-- the rest is paid through a second transaction or in cash at the counter.
+ALTER TABLE fields ADD COLUMN IF NOT EXISTS deposit_percent INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE fields ADD CONSTRAINT fields_deposit_percent_check CHECK (deposit_percent BETWEEN 0 AND 100);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS deposit_amount INTEGER NOT NULL DEFAULT 0;
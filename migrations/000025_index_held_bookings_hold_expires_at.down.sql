@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bookings_hold_expires_at;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_pending_hold_expires_at ON bookings (hold_expires_at)
+    WHERE status = 'pending';
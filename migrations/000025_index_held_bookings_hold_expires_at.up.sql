@@ -0,0 +1,6 @@
+-- Held waitlist offers expire through the same scheduled query as pending
+-- bookings, so the partial index covers both statuses.
+DROP INDEX IF EXISTS idx_bookings_pending_hold_expires_at;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_hold_expires_at ON bookings (hold_expires_at)
+    WHERE status IN ('pending', 'held');
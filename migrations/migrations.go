@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL files applied by
+// `take-home-test migrate`.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
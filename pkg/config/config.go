@@ -3,31 +3,57 @@ package config
 import (
 	"net/url"
 	"take-home-test/pkg/database"
+	"time"
+	_ "time/tzdata" // VENUE_TIMEZONE must load on hosts without zoneinfo
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	ServiceHost        string           `mapstructure:"service_host" json:"service_host"`
-	ServiceEndpointV   string           `mapstructure:"service_endpoint_v" json:"service_endpoint_v"`
-	ServiceEnvironment string           `mapstructure:"service_environment" json:"service_environment"`
-	ServicePort        string           `mapstructure:"service_port" json:"service_port"`
-	Database           DatabasePlatform `mapstructure:"database" json:"database"`
-	JWTSecret          string           `mapstructure:"jwt_secret" json:"jwt_secret"`
-	MidtransServerKey  string           `mapstructure:"midtrans_server_key" json:"midtrans_server_key"`
-	MidtransClientKey  string           `mapstructure:"midtrans_client_key" json:"midtrans_client_key"`
+	ServiceHost           string           `mapstructure:"service_host" json:"service_host"`
+	ServiceEndpointV      string           `mapstructure:"service_endpoint_v" json:"service_endpoint_v"`
+	ServiceEnvironment    string           `mapstructure:"service_environment" json:"service_environment"`
+	ServicePort           string           `mapstructure:"service_port" json:"service_port"`
+	Database              DatabasePlatform `mapstructure:"database" json:"database"`
+	JWTSecret             string           `mapstructure:"jwt_secret" json:"jwt_secret"`
+	AccessTokenTTL        time.Duration    `mapstructure:"jwt_access_token_ttl" json:"jwt_access_token_ttl"`
+	RefreshTokenTTL       time.Duration    `mapstructure:"jwt_refresh_token_ttl" json:"jwt_refresh_token_ttl"`
+	MidtransServerKey     string           `mapstructure:"midtrans_server_key" json:"midtrans_server_key"`
+	MidtransClientKey     string           `mapstructure:"midtrans_client_key" json:"midtrans_client_key"`
+	PaymentGateway        string           `mapstructure:"payment_gateway" json:"payment_gateway"`
+	RefundFullBefore      time.Duration    `mapstructure:"refund_full_before" json:"refund_full_before"`
+	RefundCutoff          time.Duration    `mapstructure:"refund_cutoff" json:"refund_cutoff"`
+	RefundPartialPercent  int              `mapstructure:"refund_partial_percent" json:"refund_partial_percent"`
+	BookingHoldTTL        time.Duration    `mapstructure:"booking_hold_ttl" json:"booking_hold_ttl"`
+	BookingExpiryInterval time.Duration    `mapstructure:"booking_expiry_interval" json:"booking_expiry_interval"`
+	VenueTimezone         string           `mapstructure:"venue_timezone" json:"venue_timezone"`
+	IdempotencyKeyTTL     time.Duration    `mapstructure:"idempotency_key_ttl" json:"idempotency_key_ttl"`
+	WaitlistOfferTTL      time.Duration    `mapstructure:"waitlist_offer_ttl" json:"waitlist_offer_ttl"`
+	SplitPaymentTTL       time.Duration    `mapstructure:"split_payment_ttl" json:"split_payment_ttl"`
 }
 
 func NewConfig() *Config {
 	return &Config{
-		ServiceHost:        viper.GetString("APP_HOST"),
-		ServiceEndpointV:   viper.GetString("APP_ENDPOINT_V"),
-		ServiceEnvironment: viper.GetString("APP_ENVIRONMENT"),
-		ServicePort:        viper.GetString("APP_PORT"),
-		Database:           LoadDatabaseConfig(),
-		JWTSecret:          viper.GetString("JWT_SECRET"),
-		MidtransServerKey:  viper.GetString("MIDTRANS_SERVER_KEY"),
-		MidtransClientKey:  viper.GetString("MIDTRANS_CLIENT_KEY"),
+		ServiceHost:           viper.GetString("APP_HOST"),
+		ServiceEndpointV:      viper.GetString("APP_ENDPOINT_V"),
+		ServiceEnvironment:    viper.GetString("APP_ENVIRONMENT"),
+		ServicePort:           viper.GetString("APP_PORT"),
+		Database:              LoadDatabaseConfig(),
+		JWTSecret:             viper.GetString("JWT_SECRET"),
+		AccessTokenTTL:        viper.GetDuration("JWT_ACCESS_TOKEN_TTL"),
+		RefreshTokenTTL:       viper.GetDuration("JWT_REFRESH_TOKEN_TTL"),
+		MidtransServerKey:     viper.GetString("MIDTRANS_SERVER_KEY"),
+		MidtransClientKey:     viper.GetString("MIDTRANS_CLIENT_KEY"),
+		PaymentGateway:        viper.GetString("PAYMENT_GATEWAY"),
+		RefundFullBefore:      viper.GetDuration("REFUND_FULL_BEFORE"),
+		RefundCutoff:          viper.GetDuration("REFUND_CUTOFF"),
+		RefundPartialPercent:  viper.GetInt("REFUND_PARTIAL_PERCENT"),
+		BookingHoldTTL:        viper.GetDuration("BOOKING_HOLD_TTL"),
+		BookingExpiryInterval: viper.GetDuration("BOOKING_EXPIRY_INTERVAL"),
+		VenueTimezone:         viper.GetString("VENUE_TIMEZONE"),
+		IdempotencyKeyTTL:     viper.GetDuration("IDEMPOTENCY_KEY_TTL"),
+		WaitlistOfferTTL:      viper.GetDuration("WAITLIST_OFFER_TTL"),
+		SplitPaymentTTL:       viper.GetDuration("SPLIT_PAYMENT_TTL"),
 	}
 }
 
@@ -38,6 +64,95 @@ func (c *Config) GetJWTSecret() string {
 	return c.JWTSecret
 }
 
+func (c *Config) GetAccessTokenTTL() time.Duration {
+	if c.AccessTokenTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return c.AccessTokenTTL
+}
+
+func (c *Config) GetRefreshTokenTTL() time.Duration {
+	if c.RefreshTokenTTL <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return c.RefreshTokenTTL
+}
+
+func (c *Config) GetRefundFullBefore() time.Duration {
+	if c.RefundFullBefore <= 0 {
+		return 24 * time.Hour
+	}
+	return c.RefundFullBefore
+}
+
+func (c *Config) GetRefundCutoff() time.Duration {
+	if c.RefundCutoff <= 0 {
+		return 2 * time.Hour
+	}
+	return c.RefundCutoff
+}
+
+// GetRefundPartialPercent is the share refunded between the cutoff and the
+// full-refund window, clamped to 0-100.
+func (c *Config) GetRefundPartialPercent() int {
+	switch {
+	case c.RefundPartialPercent < 0:
+		return 0
+	case c.RefundPartialPercent > 100:
+		return 100
+	}
+	return c.RefundPartialPercent
+}
+
+// GetBookingHoldTTL is how long a pending booking holds its slot before it
+// expires unpaid.
+func (c *Config) GetBookingHoldTTL() time.Duration {
+	if c.BookingHoldTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return c.BookingHoldTTL
+}
+
+// GetWaitlistOfferTTL is how long a freed slot stays held for the
+// waitlisted user it is offered to.
+func (c *Config) GetWaitlistOfferTTL() time.Duration {
+	if c.WaitlistOfferTTL <= 0 {
+		return 30 * time.Minute
+	}
+	return c.WaitlistOfferTTL
+}
+
+// GetSplitPaymentTTL is how long the participants of a split payment have to
+// pay their shares when the owner sets no deadline.
+func (c *Config) GetSplitPaymentTTL() time.Duration {
+	if c.SplitPaymentTTL <= 0 {
+		return 24 * time.Hour
+	}
+	return c.SplitPaymentTTL
+}
+
+func (c *Config) GetBookingExpiryInterval() time.Duration {
+	if c.BookingExpiryInterval <= 0 {
+		return time.Minute
+	}
+	return c.BookingExpiryInterval
+}
+
+// GetVenueLocation is the timezone the venue operates in, used to interpret
+// calendar dates. Unknown zones fall back to UTC.
+func (c *Config) GetVenueLocation() *time.Location {
+	name := c.VenueTimezone
+	if name == "" {
+		name = "Asia/Jakarta"
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func (d *Database) ToArgs(dbType database.DBType, connType database.ConnType, val url.Values) (res *database.Args) {
 	res = &database.Args{
 		Username:        d.Username,
@@ -59,3 +174,12 @@ func (d *Database) ToArgs(dbType database.DBType, connType database.ConnType, va
 	}
 	return
 }
+
+// GetIdempotencyKeyTTL is how long a response stored for an Idempotency-Key
+// is replayed.
+func (c *Config) GetIdempotencyKeyTTL() time.Duration {
+	if c.IdempotencyKeyTTL <= 0 {
+		return 24 * time.Hour
+	}
+	return c.IdempotencyKeyTTL
+}
@@ -22,6 +22,10 @@ func GetStatusCode(err error) int {
 		return http.StatusNotFound
 	} else if _, ok := err.(BadRequestError); ok {
 		return http.StatusBadRequest
+	} else if _, ok := err.(UnauthorizedError); ok {
+		return http.StatusUnauthorized
+	} else if _, ok := err.(ConflictError); ok {
+		return http.StatusConflict
 	}
 	return http.StatusInternalServerError
 
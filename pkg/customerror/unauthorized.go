@@ -0,0 +1,28 @@
+package customerror
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type unauthorized struct {
+	TrackableError
+}
+
+type UnauthorizedError interface {
+	error
+	IsUnauthorizedError() bool
+}
+
+func (e *unauthorized) IsUnauthorizedError() bool { return true }
+
+func NewUnauthorizedErrorf(format string, data ...interface{}) (err error) {
+	err = errors.New(fmt.Sprintf(format, data...))
+	return &unauthorized{TrackableError{err}}
+}
+
+func NewUnauthorizedError(message string) (err error) {
+	err = errors.New(message)
+	return &unauthorized{TrackableError{err}}
+}
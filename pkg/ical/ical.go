@@ -0,0 +1,171 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+)
+
+var ErrNoCalendar = errors.New("no VCALENDAR found")
+
+// Event is the part of a VEVENT needed to import closures. End is exclusive,
+// as in iCalendar; all-day events start and end at midnight UTC of their
+// dates.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Days lists the calendar dates covered by the event, in the location of its
+// start, formatted as YYYY-MM-DD. An instant event covers the date it
+// happens on.
+func (e Event) Days() (res []string) {
+	last := e.Start
+	if e.End.After(e.Start) {
+		last = e.End.Add(-time.Nanosecond).In(e.Start.Location())
+	}
+
+	day := time.Date(e.Start.Year(), e.Start.Month(), e.Start.Day(), 0, 0, 0, 0, time.UTC)
+	lastDay := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	for ; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		res = append(res, day.Format("2006-01-02"))
+	}
+	return
+}
+
+// Parse reads the VEVENTs of an iCalendar (RFC 5545) stream. Only DTSTART,
+// DTEND, SUMMARY and UID are interpreted; recurrence rules are not expanded.
+// Date-times are read in their TZID location when known, UTC otherwise.
+func Parse(r io.Reader) (res []Event, err error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return
+	}
+
+	var (
+		inCalendar bool
+		event      *Event
+	)
+	for i, line := range lines {
+		name, params, value := splitLine(line)
+
+		switch {
+		case name == "BEGIN" && value == "VCALENDAR":
+			inCalendar = true
+		case name == "BEGIN" && value == "VEVENT":
+			event = &Event{}
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				err = errors.Errorf("line %d: END:VEVENT without BEGIN", i+1)
+				return
+			}
+			if event.Start.IsZero() {
+				err = errors.Errorf("line %d: event %q has no DTSTART", i+1, event.Summary)
+				return
+			}
+			if event.End.IsZero() {
+				// RFC 5545: an all-day event lasts one day, others are instants
+				event.End = event.Start
+				if event.AllDay {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+			}
+			res = append(res, *event)
+			event = nil
+		case event == nil:
+			continue
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescape(value)
+		case name == "DTSTART", name == "DTEND":
+			t, allDay, parseErr := parseTime(params, value)
+			if parseErr != nil {
+				err = errors.Wrapf(parseErr, "line %d", i+1)
+				return
+			}
+			if name == "DTSTART" {
+				event.Start, event.AllDay = t, allDay
+			} else {
+				event.End = t
+			}
+		}
+	}
+
+	if !inCalendar {
+		err = ErrNoCalendar
+	}
+	return
+}
+
+// unfold joins continuation lines (starting with a space or tab) to the
+// line they continue.
+func unfold(r io.Reader) (lines []string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	err = scanner.Err()
+	return
+}
+
+// splitLine splits "NAME;PARAM=X:VALUE" into its upper-cased name, the
+// parameters and the raw value.
+func splitLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, part := range parts[1:] {
+		key, val, _ := strings.Cut(part, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return
+}
+
+func parseTime(params map[string]string, value string) (t time.Time, allDay bool, err error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		t, err = time.Parse(dateFormat, value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(dateTimeFormat, strings.TrimSuffix(value, "Z"))
+		return
+	}
+
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if l, loadErr := time.LoadLocation(tzid); loadErr == nil {
+			loc = l
+		}
+	}
+	t, err = time.ParseInLocation(dateTimeFormat, value, loc)
+	return
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
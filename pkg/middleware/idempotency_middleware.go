@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"take-home-test/pkg/customerror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotentResponse is a stored response replayed for a retried request.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore keeps the first response per user and Idempotency-Key.
+// BeginIdempotentRequest either reserves the key for a new request (nil
+// response) or returns the stored response of the same request; a key in
+// use by a different or unfinished request is an error.
+type IdempotencyStore interface {
+	BeginIdempotentRequest(ctx context.Context, userID, key, requestHash string) (*IdempotentResponse, error)
+	CompleteIdempotentRequest(ctx context.Context, userID, key string, response IdempotentResponse) error
+	ReleaseIdempotentRequest(ctx context.Context, userID, key string) error
+}
+
+// NewIdempotencyMiddleware replays the stored response when a request is
+// retried with the same Idempotency-Key header. It must run after the JWT
+// middleware since keys are scoped per user; requests without the header
+// pass through. Server errors are not stored, so such requests can be
+// retried with the same key.
+func NewIdempotencyMiddleware(store IdempotencyStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+		userID, _ := c.Locals("userID").(string)
+		if key == "" || userID == "" {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status_code": fiber.StatusBadRequest,
+				"message":     "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		stored, err := store.BeginIdempotentRequest(c.Context(), userID, key, requestHash(c))
+		if err != nil {
+			statusCode := customerror.GetStatusCode(err)
+			return c.Status(statusCode).JSON(fiber.Map{
+				"status_code": statusCode,
+				"message":     err.Error(),
+			})
+		}
+
+		if stored != nil {
+			c.Set(IdempotentReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, stored.ContentType)
+			return c.Status(stored.StatusCode).Send(stored.Body)
+		}
+
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(c, store, userID, key)
+			return err
+		}
+
+		statusCode := c.Response().StatusCode()
+		if statusCode >= fiber.StatusInternalServerError {
+			releaseIdempotencyKey(c, store, userID, key)
+			return nil
+		}
+
+		err = store.CompleteIdempotentRequest(c.Context(), userID, key, IdempotentResponse{
+			StatusCode:  statusCode,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		})
+		if err != nil {
+			log.Printf("storing response for idempotency key %s: %v", key, err)
+		}
+		return nil
+	}
+}
+
+// requestHash fingerprints the method, URL and body of the request.
+func requestHash(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func releaseIdempotencyKey(c *fiber.Ctx, store IdempotencyStore, userID, key string) {
+	if err := store.ReleaseIdempotentRequest(c.Context(), userID, key); err != nil {
+		log.Printf("releasing idempotency key %s: %v", key, err)
+	}
+}
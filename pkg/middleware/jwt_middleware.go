@@ -1,62 +1,111 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 	"take-home-test/pkg/config"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
-// JWTMiddleware validates JWT token
-func JWTMiddleware(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"status_code": fiber.StatusUnauthorized,
-			"message":     "Authorization header is required",
-		})
-	}
+// TokenRevocationChecker reports whether an access token, or the session it
+// was issued for, has been revoked (e.g. by logout).
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error)
+}
+
+// NewJWTMiddleware validates JWT token and rejects tokens whose jti or
+// session has been revoked
+func NewJWTMiddleware(cfg *config.Config, checker TokenRevocationChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Authorization header is required",
+			})
+		}
 
-	// Extract token from "Bearer <token>"
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"status_code": fiber.StatusUnauthorized,
-			"message":     "Invalid authorization format",
+		// Extract token from "Bearer <token>"
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid authorization format",
+			})
+		}
+
+		tokenString := parts[1]
+
+		// Parse and validate token
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			// Validate signing method
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid signing method")
+			}
+			return []byte(cfg.GetJWTSecret()), nil
 		})
-	}
 
-	tokenString := parts[1]
+		if err != nil || !token.Valid {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid or expired token",
+			})
+		}
 
-	// Create config instance
-	cfg := config.NewConfig()
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid or expired token",
+			})
+		}
 
-	// Parse and validate token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid signing method")
+		// Tokens without a jti/session cannot be revoked, so they are not accepted
+		tokenID, _ := claims["jti"].(string)
+		sessionID, _ := claims["sid"].(string)
+		if _, err := uuid.Parse(tokenID); err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid or expired token",
+			})
+		}
+		if _, err := uuid.Parse(sessionID); err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid or expired token",
+			})
 		}
-		return []byte(cfg.GetJWTSecret()), nil
-	})
 
-	if err != nil || !token.Valid {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"status_code": fiber.StatusUnauthorized,
-			"message":     "Invalid or expired token",
-		})
-	}
+		revoked, err := checker.IsTokenRevoked(c.Context(), tokenID, sessionID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status_code": fiber.StatusInternalServerError,
+				"message":     "Internal server error",
+			})
+		}
+		if revoked {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Token has been revoked",
+			})
+		}
 
-	// Extract claims
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
 		// Set user info in context
 		c.Locals("userID", claims["user_id"])
 		c.Locals("email", claims["email"])
 		c.Locals("role", claims["role"])
-	}
+		c.Locals("jti", tokenID)
+		c.Locals("sessionID", sessionID)
+		if exp, ok := claims["exp"].(float64); ok {
+			c.Locals("tokenExpiresAt", time.Unix(int64(exp), 0))
+		}
 
-	return c.Next()
+		return c.Next()
+	}
 }
 
 // AdminMiddleware checks if user has admin role
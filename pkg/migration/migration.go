@@ -0,0 +1,368 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// TableName is the bookkeeping table that records applied migrations.
+const TableName = "schema_migrations"
+
+// lockKey is the pg_advisory_xact_lock key used to serialize migration runs
+// started from several processes at the same time.
+const lockKey int64 = 7_301_994_221
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrMissingDown      = errors.New("missing down migration")
+)
+
+// Migration is a single versioned schema change loaded from a pair of
+// <version>_<name>.up.sql and <version>_<name>.down.sql files.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status describes the state of a migration compared to the database.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Modified  bool // applied with a different checksum than the file on disk
+	Missing   bool // applied in the database but not present on disk
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	schema     string
+	migrations []Migration
+}
+
+// New creates a Migrator for the migrations found in fsys. The schema is
+// created when missing; tables themselves are created unqualified so they
+// follow the search_path of the connection.
+func New(db *gorm.DB, schema string, fsys fs.FS) (m *Migrator, err error) {
+	if db == nil {
+		err = errors.New("nil database connection")
+		return
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return
+	}
+
+	m = &Migrator{
+		db:         db,
+		schema:     schema,
+		migrations: migrations,
+	}
+	return
+}
+
+// Load reads and validates every migration file in the root of fsys.
+func Load(fsys fs.FS) (res []Migration, err error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			err = errors.Errorf("invalid migration file name %q", entry.Name())
+			return
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, readErr := fs.ReadFile(fsys, entry.Name())
+		if readErr != nil {
+			err = readErr
+			return
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		} else if mig.Name != match[2] {
+			err = errors.Errorf("migration version %d is used by both %q and %q", version, mig.Name, match[2])
+			return
+		}
+
+		switch match[3] {
+		case "up":
+			mig.Up = string(content)
+		case "down":
+			mig.Down = string(content)
+		}
+	}
+
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" {
+			err = errors.Errorf("migration %d_%s has no up script", mig.Version, mig.Name)
+			return
+		}
+		mig.Checksum = checksum(mig.Up)
+		res = append(res, *mig)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction together with its schema_migrations record.
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	done, err := m.prepare(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, mig := range m.migrations {
+		if _, ok := done[mig.Version]; ok {
+			continue
+		}
+
+		skipped := false
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+
+			// Another process may have applied it while we waited for the lock.
+			var count int64
+			if err := tx.Table(TableName).Where("version = ?", mig.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				skipped = true
+				return nil
+			}
+
+			if err := tx.Exec(mig.Up).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(
+				fmt.Sprintf("INSERT INTO %s (version, name, checksum) VALUES (?, ?, ?)", TableName),
+				mig.Version, mig.Name, mig.Checksum,
+			).Error
+		})
+		if err != nil {
+			err = errors.Wrapf(err, "applying migration %d_%s", mig.Version, mig.Name)
+			return
+		}
+
+		if !skipped {
+			applied = append(applied, mig)
+		}
+	}
+	return
+}
+
+// Down reverts the latest `steps` applied migrations, newest first. Applied
+// versions without a file on disk are left alone.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	if _, err = m.prepare(ctx); err != nil {
+		return
+	}
+
+	for len(reverted) < steps {
+		var (
+			mig         Migration
+			found       bool
+			missingDown bool
+		)
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+
+			// Another process may have reverted migrations while we waited
+			// for the lock, so the latest one is only picked now.
+			var versions []int64
+			if err := tx.Table(TableName).Order("version DESC").Pluck("version", &versions).Error; err != nil {
+				return err
+			}
+			mig, found = m.latest(versions)
+			if !found {
+				return nil
+			}
+
+			if strings.TrimSpace(mig.Down) == "" {
+				missingDown = true
+				return nil
+			}
+
+			if err := tx.Exec(mig.Down).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", TableName), mig.Version).Error
+		})
+		if err != nil {
+			err = errors.Wrapf(err, "reverting migration %d_%s", mig.Version, mig.Name)
+			return
+		}
+		if missingDown {
+			err = errors.Wrapf(ErrMissingDown, "migration %d_%s", mig.Version, mig.Name)
+			return
+		}
+		if !found {
+			return
+		}
+
+		reverted = append(reverted, mig)
+	}
+	return
+}
+
+// latest returns the newest of the applied versions, given newest first,
+// that has a migration on disk.
+func (m *Migrator) latest(versions []int64) (Migration, bool) {
+	for _, version := range versions {
+		for _, mig := range m.migrations {
+			if mig.Version == version {
+				return mig, true
+			}
+		}
+	}
+	return Migration{}, false
+}
+
+// Status lists every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) (res []Status, err error) {
+	if err = m.ensureTable(ctx); err != nil {
+		return
+	}
+
+	done, err := m.applied(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, mig := range m.migrations {
+		status := Status{Version: mig.Version, Name: mig.Name}
+		if row, ok := done[mig.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = row.Checksum != mig.Checksum
+			delete(done, mig.Version)
+		}
+		res = append(res, status)
+	}
+
+	for _, row := range done {
+		appliedAt := row.AppliedAt
+		res = append(res, Status{
+			Version:   row.Version,
+			Name:      row.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return
+}
+
+// prepare makes sure the bookkeeping table exists and refuses to continue
+// when an applied migration was edited afterwards.
+func (m *Migrator) prepare(ctx context.Context) (done map[int64]appliedMigration, err error) {
+	if err = m.ensureTable(ctx); err != nil {
+		return
+	}
+
+	done, err = m.applied(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, mig := range m.migrations {
+		row, ok := done[mig.Version]
+		if ok && row.Checksum != mig.Checksum {
+			err = errors.Wrapf(ErrChecksumMismatch, "migration %d_%s was modified after being applied", mig.Version, mig.Name)
+			return
+		}
+	}
+	return
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) (err error) {
+	db := m.db.WithContext(ctx)
+
+	if m.schema != "" {
+		err = db.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", quoteIdent(m.schema))).Error
+		if err != nil {
+			return
+		}
+	}
+
+	err = db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`, TableName)).Error
+	return
+}
+
+func (m *Migrator) applied(ctx context.Context) (res map[int64]appliedMigration, err error) {
+	var rows []appliedMigration
+	err = m.db.WithContext(ctx).Table(TableName).Order("version").Find(&rows).Error
+	if err != nil {
+		return
+	}
+
+	res = make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		res[row.Version] = row
+	}
+	return
+}
+
+func lock(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error
+}
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
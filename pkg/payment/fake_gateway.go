@@ -0,0 +1,285 @@
+package payment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/midtrans/midtrans-go/snap"
+)
+
+const fakeTimeFormat = "2006-01-02 15:04:05"
+
+// FakeGateway is an in-process stand-in for Midtrans used to run payment
+// flows offline. Transactions live in memory and are moved to a final state
+// with Simulate, which returns the notification Midtrans would have posted.
+type FakeGateway struct {
+	mu           sync.Mutex
+	baseURL      string
+	serverKey    string
+	transactions map[string]*fakeTransaction
+}
+
+type fakeTransaction struct {
+	orderID           string
+	transactionID     string
+	grossAmount       int64
+	transactionStatus string
+	paymentType       string
+	refunds           []coreapi.RefundDetails
+	transactionTime   time.Time
+	settlementTime    time.Time
+}
+
+var _ PaymentGateway = (*FakeGateway)(nil)
+var _ Simulator = (*FakeGateway)(nil)
+
+// NewFakeGateway creates a fake gateway; serverKey is only used to sign the
+// simulated notifications the same way Midtrans does.
+func NewFakeGateway(baseURL, serverKey string) *FakeGateway {
+	return &FakeGateway{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		serverKey:    serverKey,
+		transactions: make(map[string]*fakeTransaction),
+	}
+}
+
+func (f *FakeGateway) CreateTransaction(orderID string, amount int64, customerName, customerEmail string, items []Item) (*snap.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.transactions[orderID]; ok {
+		return nil, fmt.Errorf("failed to create transaction: order_id %s has already been taken", orderID)
+	}
+
+	// Midtrans rejects item details that do not add up to the gross amount
+	var total int64
+	for _, item := range items {
+		total += item.Price
+	}
+	if total != amount {
+		return nil, fmt.Errorf("failed to create transaction: item prices add up to %d instead of the gross amount %d", total, amount)
+	}
+
+	f.transactions[orderID] = &fakeTransaction{
+		orderID:           orderID,
+		transactionID:     uuid.New().String(),
+		grossAmount:       amount,
+		transactionStatus: "pending",
+		transactionTime:   time.Now(),
+	}
+
+	return &snap.Response{
+		Token:       uuid.New().String(),
+		RedirectURL: fmt.Sprintf("%s/api/payments/fake/%s", f.baseURL, orderID),
+	}, nil
+}
+
+func (f *FakeGateway) GetTransactionDetails(orderID string) (*coreapi.TransactionStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	trx, ok := f.transactions[orderID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get transaction details: transaction %s doesn't exist", orderID)
+	}
+
+	return trx.statusResponse(), nil
+}
+
+func (f *FakeGateway) CheckTransactionStatus(orderID string) (*coreapi.TransactionStatusResponse, error) {
+	return f.GetTransactionDetails(orderID)
+}
+
+// Expire moves a pending transaction to "expire".
+func (f *FakeGateway) Expire(orderID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	trx, ok := f.transactions[orderID]
+	if !ok {
+		return fmt.Errorf("failed to expire transaction: transaction %s doesn't exist", orderID)
+	}
+	if trx.transactionStatus != "pending" {
+		return fmt.Errorf("failed to expire transaction: transaction status is %s", trx.transactionStatus)
+	}
+
+	trx.transactionStatus = "expire"
+	return nil
+}
+
+// Refund refunds a settled transaction, moving it to "partial_refund" or,
+// once everything is returned, "refund". A refund key is only applied once.
+func (f *FakeGateway) Refund(orderID, refundKey string, amount int64, reason string) (*coreapi.RefundResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	trx, ok := f.transactions[orderID]
+	if !ok {
+		return nil, fmt.Errorf("failed to refund transaction: transaction %s doesn't exist", orderID)
+	}
+
+	refund, err := trx.refund(refundKey, amount, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund transaction: %v", err)
+	}
+
+	return &coreapi.RefundResponse{
+		StatusCode:         "200",
+		StatusMessage:      "Success, refund request is approved",
+		TransactionID:      trx.transactionID,
+		OrderID:            trx.orderID,
+		GrossAmount:        strconv.FormatInt(trx.grossAmount, 10) + ".00",
+		Currency:           "IDR",
+		PaymentType:        trx.paymentType,
+		TransactionStatus:  trx.transactionStatus,
+		RefundChargebackID: refund.RefundChargebackID,
+		RefundAmount:       refund.RefundAmount,
+		RefundKey:          refund.RefundKey,
+	}, nil
+}
+
+// Simulate settles, denies or expires a transaction as if the customer had
+// finished (or abandoned) the payment on the provider side. "refund" refunds
+// whatever is left of a settled transaction, as if done from the dashboard.
+func (f *FakeGateway) Simulate(orderID, transactionStatus, paymentType string) (map[string]interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	trx, ok := f.transactions[orderID]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s doesn't exist", orderID)
+	}
+
+	switch transactionStatus {
+	case "capture", "settlement", "pending", "deny", "cancel", "expire", "failure":
+	case "refund":
+		refundKey := fmt.Sprintf("%s-dashboard-%d", orderID, len(trx.refunds)+1)
+		if _, err := trx.refund(refundKey, trx.grossAmount-trx.refundedAmount(), "Refunded from dashboard"); err != nil {
+			return nil, err
+		}
+		return trx.notification(f.serverKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported transaction status %q", transactionStatus)
+	}
+
+	if paymentType == "" {
+		paymentType = "bank_transfer"
+	}
+
+	trx.transactionStatus = transactionStatus
+	trx.paymentType = paymentType
+	if transactionStatus == "settlement" || transactionStatus == "capture" {
+		trx.settlementTime = time.Now()
+	}
+
+	return trx.notification(f.serverKey), nil
+}
+
+// notification builds the payload Midtrans posts for the transaction's
+// current state, signed with serverKey.
+func (t *fakeTransaction) notification(serverKey string) map[string]interface{} {
+	resp := t.statusResponse()
+	payload := map[string]interface{}{
+		"transaction_time":   resp.TransactionTime,
+		"transaction_status": resp.TransactionStatus,
+		"transaction_id":     resp.TransactionID,
+		"status_message":     resp.StatusMessage,
+		"status_code":        resp.StatusCode,
+		"payment_type":       resp.PaymentType,
+		"order_id":           resp.OrderID,
+		"gross_amount":       resp.GrossAmount,
+		"fraud_status":       resp.FraudStatus,
+		"currency":           resp.Currency,
+		"settlement_time":    resp.SettlementTime,
+		"signature_key":      SignatureKey(resp.OrderID, resp.StatusCode, resp.GrossAmount, serverKey),
+	}
+	if resp.RefundAmount != "" {
+		payload["refund_amount"] = resp.RefundAmount
+	}
+	return payload
+}
+
+// refund applies a refund to a settled transaction, moving it to
+// "partial_refund" or, once everything is returned, "refund". A refund key
+// is only applied once; repeating it returns the original refund.
+func (t *fakeTransaction) refund(refundKey string, amount int64, reason string) (coreapi.RefundDetails, error) {
+	for _, refund := range t.refunds {
+		if refund.RefundKey == refundKey {
+			return refund, nil
+		}
+	}
+
+	switch t.transactionStatus {
+	case "capture", "settlement", "partial_refund":
+	default:
+		return coreapi.RefundDetails{}, fmt.Errorf("transaction status %s cannot be refunded", t.transactionStatus)
+	}
+
+	if amount <= 0 || t.refundedAmount()+amount > t.grossAmount {
+		return coreapi.RefundDetails{}, fmt.Errorf("invalid refund amount %d", amount)
+	}
+
+	refund := coreapi.RefundDetails{
+		RefundChargebackID: len(t.refunds) + 1,
+		RefundAmount:       strconv.FormatInt(amount, 10) + ".00",
+		Reason:             reason,
+		RefundKey:          refundKey,
+		CreatedAt:          time.Now().Format(fakeTimeFormat),
+	}
+	t.refunds = append(t.refunds, refund)
+
+	t.transactionStatus = "partial_refund"
+	if t.refundedAmount() == t.grossAmount {
+		t.transactionStatus = "refund"
+	}
+	return refund, nil
+}
+
+func (t *fakeTransaction) refundedAmount() (total int64) {
+	for _, refund := range t.refunds {
+		amount, _ := strconv.ParseFloat(refund.RefundAmount, 64)
+		total += int64(amount)
+	}
+	return
+}
+
+func (t *fakeTransaction) statusResponse() *coreapi.TransactionStatusResponse {
+	resp := &coreapi.TransactionStatusResponse{
+		TransactionTime:   t.transactionTime.Format(fakeTimeFormat),
+		GrossAmount:       strconv.FormatInt(t.grossAmount, 10) + ".00",
+		Currency:          "IDR",
+		OrderID:           t.orderID,
+		PaymentType:       t.paymentType,
+		StatusCode:        fakeStatusCode(t.transactionStatus),
+		TransactionID:     t.transactionID,
+		TransactionStatus: t.transactionStatus,
+		FraudStatus:       "accept",
+		StatusMessage:     "Success, transaction is found",
+	}
+	if !t.settlementTime.IsZero() {
+		resp.SettlementTime = t.settlementTime.Format(fakeTimeFormat)
+	}
+	if len(t.refunds) > 0 {
+		resp.Refunds = t.refunds
+		resp.RefundAmount = strconv.FormatInt(t.refundedAmount(), 10) + ".00"
+	}
+	return resp
+}
+
+func fakeStatusCode(transactionStatus string) string {
+	switch transactionStatus {
+	case "capture", "settlement", "refund", "partial_refund":
+		return "200"
+	case "pending":
+		return "201"
+	case "expire":
+		return "407"
+	default:
+		return "202"
+	}
+}
@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"fmt"
+
+	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/midtrans/midtrans-go/snap"
+)
+
+const (
+	ProviderMidtrans = "midtrans"
+	ProviderFake     = "fake"
+)
+
+// PaymentGateway is the payment provider used by the booking flow. Requests
+// and responses use the Midtrans shapes, which every provider emulates.
+type PaymentGateway interface {
+	CreateTransaction(orderID string, amount int64, customerName, customerEmail string, items []Item) (*snap.Response, error)
+	GetTransactionDetails(orderID string) (*coreapi.TransactionStatusResponse, error)
+	CheckTransactionStatus(orderID string) (*coreapi.TransactionStatusResponse, error)
+	Refund(orderID, refundKey string, amount int64, reason string) (*coreapi.RefundResponse, error)
+	Expire(orderID string) error
+}
+
+// Item is one line of a transaction. The prices of a transaction's items
+// add up to its amount.
+type Item struct {
+	ID    string
+	Name  string
+	Price int64
+}
+
+// Simulator is implemented by gateways that can fake the provider side of a
+// payment, returning the notification payload the provider would send.
+type Simulator interface {
+	Simulate(orderID, transactionStatus, paymentType string) (map[string]interface{}, error)
+}
+
+// NewPaymentGateway builds the gateway selected by provider; an empty
+// provider means Midtrans. The fake gateway lets anyone settle a payment
+// through its unauthenticated endpoints, so it is refused in production.
+func NewPaymentGateway(provider, serverKey string, isProduction bool, baseURL string) (PaymentGateway, error) {
+	switch provider {
+	case "", ProviderMidtrans:
+		return NewMidtransService(serverKey, isProduction), nil
+	case ProviderFake:
+		if isProduction {
+			return nil, fmt.Errorf("payment gateway %q cannot be used in production", provider)
+		}
+		return NewFakeGateway(baseURL, serverKey), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway %q", provider)
+	}
+}
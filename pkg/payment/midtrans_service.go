@@ -8,6 +8,8 @@ import (
 	"github.com/midtrans/midtrans-go/snap"
 )
 
+var _ PaymentGateway = (*MidtransService)(nil)
+
 type MidtransService struct {
 	snapClient    snap.Client
 	coreApiClient coreapi.Client
@@ -29,7 +31,17 @@ func NewMidtransService(serverKey string, isProduction bool) *MidtransService {
 	return s
 }
 
-func (m *MidtransService) CreateTransaction(orderID string, amount int64, customerName, customerEmail, itemName string) (*snap.Response, error) {
+func (m *MidtransService) CreateTransaction(orderID string, amount int64, customerName, customerEmail string, items []Item) (*snap.Response, error) {
+	itemDetails := make([]midtrans.ItemDetails, 0, len(items))
+	for _, item := range items {
+		itemDetails = append(itemDetails, midtrans.ItemDetails{
+			ID:    item.ID,
+			Name:  item.Name,
+			Price: item.Price,
+			Qty:   1,
+		})
+	}
+
 	req := &snap.Request{
 		TransactionDetails: midtrans.TransactionDetails{
 			OrderID:  orderID,
@@ -39,14 +51,7 @@ func (m *MidtransService) CreateTransaction(orderID string, amount int64, custom
 			FName: customerName,
 			Email: customerEmail,
 		},
-		Items: &[]midtrans.ItemDetails{
-			{
-				ID:    orderID,
-				Name:  itemName,
-				Price: amount,
-				Qty:   1,
-			},
-		},
+		Items:           &itemDetails,
 		EnabledPayments: snap.AllSnapPaymentType,
 	}
 
@@ -70,3 +75,26 @@ func (m *MidtransService) GetTransactionDetails(orderID string) (*coreapi.Transa
 func (m *MidtransService) CheckTransactionStatus(orderID string) (*coreapi.TransactionStatusResponse, error) {
 	return m.GetTransactionDetails(orderID)
 }
+
+// Expire closes a transaction that is still pending so it can no longer be
+// paid.
+func (m *MidtransService) Expire(orderID string) error {
+	if _, err := m.coreApiClient.ExpireTransaction(orderID); err != nil {
+		return fmt.Errorf("failed to expire transaction: %v", err)
+	}
+	return nil
+}
+
+// Refund returns part or all of a settled transaction. Midtrans deduplicates
+// requests by refundKey, so retrying with the same key is safe.
+func (m *MidtransService) Refund(orderID, refundKey string, amount int64, reason string) (*coreapi.RefundResponse, error) {
+	resp, err := m.coreApiClient.RefundTransaction(orderID, &coreapi.RefundReq{
+		RefundKey: refundKey,
+		Amount:    amount,
+		Reason:    reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund transaction: %v", err)
+	}
+	return resp, nil
+}
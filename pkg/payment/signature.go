@@ -0,0 +1,22 @@
+package payment
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureKey computes the signature Midtrans attaches to HTTP
+// notifications: SHA512(order_id + status_code + gross_amount + server_key).
+func SignatureKey(orderID, statusCode, grossAmount, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifySignatureKey reports whether signature matches the notification
+// fields, comparing in constant time.
+func VerifySignatureKey(orderID, statusCode, grossAmount, serverKey, signature string) bool {
+	expected := SignatureKey(orderID, statusCode, grossAmount, serverKey)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(signature))) == 1
+}